@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/ini.v1"
+)
+
+const (
+	DEFAULT_SECTION = "default"
+	CONFIG_FILE_ENV = "CLOUDCTL_CONFIG_FILE"
+)
+
+// Config holds cloudctl settings read from an ini file, settings can be kept in the
+// `default` section or in a section named after the profile which takes precedence.
+type Config struct {
+	file *ini.File
+}
+
+// Load read config from `CLOUDCTL_CONFIG_FILE` or ~/.cloudctl/config, missing file results in an empty config
+func Load() *Config {
+	path := os.Getenv(CONFIG_FILE_ENV)
+	if len(path) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return &Config{}
+		}
+		path = filepath.Join(home, ".cloudctl", "config")
+	}
+	f, err := ini.Load(path)
+	if err != nil {
+		return &Config{}
+	}
+	return &Config{file: f}
+}
+
+// Value return key value for the profile, fallback to the default section
+func (c *Config) Value(profile, key string) string {
+	if c.file == nil {
+		return ""
+	}
+	if len(profile) != 0 && c.file.HasSection(profile) {
+		if section := c.file.Section(profile); section.HasKey(key) {
+			return section.Key(key).String()
+		}
+	}
+	if c.file.HasSection(DEFAULT_SECTION) {
+		return c.file.Section(DEFAULT_SECTION).Key(key).String()
+	}
+	return ""
+}
+
+// Bool return key value as bool, nil if key is not configured or not a valid bool
+func (c *Config) Bool(profile, key string) *bool {
+	value := c.Value(profile, key)
+	if len(value) == 0 {
+		return nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil
+	}
+	return &b
+}
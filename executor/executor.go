@@ -9,22 +9,60 @@ import (
 	"github.com/fatih/color"
 )
 
+// Failure is implemented by fetched data which fails the command after it is viewed,
+// e.g. an audit with critical findings
+type Failure interface {
+	Failure() error
+}
+
+// Applier is implemented by fetchers of commands which change resources, fetched data is the plan
+// which is viewed with PlanViewer and applied once confirmed
+type Applier interface {
+	// Confirmation return prompt message, empty when there is nothing to apply
+	Confirmation(plan interface{}) string
+	// Apply return data viewed with Viewer, confirmed is false when prompt is declined
+	Apply(plan interface{}, confirmed bool) interface{}
+}
+
 type CommandExecutor struct {
 	Fetcher fetcher.Fetcher
 	Viewer  viewer.ViewerFunc
+	// PlanViewer & Confirm are used only when Fetcher is an Applier
+	PlanViewer viewer.ViewerFunc
+	Confirm    func(message string) bool
 }
 
 func (exe *CommandExecutor) Execute() error {
 	start := time.Now()
 	data := exe.Fetcher.Fetch()
+	if applier, ok := exe.Fetcher.(Applier); ok {
+		data = exe.apply(applier, data)
+	}
 	view := exe.Viewer(data)
 	view.View()
+	if err := viewer.ColumnSelectionError(); err != nil {
+		return err
+	}
+	if failure, ok := data.(Failure); ok {
+		if err := failure.Failure(); err != nil {
+			return err
+		}
+	}
 	if view.IsErrorView() {
 		return nil
 	}
 	black := color.New(color.FgGreen)
 	boldBlack := black.Add(color.Bold)
-	defer boldBlack.Println("Time elapsed:", fmt.Sprintf("%.2f", time.Since(start).Seconds()), "sec")
+	boldBlack.Println("Time elapsed:", fmt.Sprintf("%.2f", time.Since(start).Seconds()), "sec")
 
 	return nil
 }
+
+func (exe *CommandExecutor) apply(applier Applier, plan interface{}) interface{} {
+	message := applier.Confirmation(plan)
+	if len(message) == 0 {
+		return plan
+	}
+	exe.PlanViewer(plan).View()
+	return applier.Apply(plan, exe.Confirm(message))
+}
@@ -0,0 +1,113 @@
+package expr
+
+import (
+	"regexp"
+)
+
+type node interface {
+	typ() Type
+	eval(record Record) value
+}
+
+type fieldNode struct {
+	name string
+	// key of map field e.g. env of tags.env
+	key       string
+	fieldType Type
+}
+
+func (n *fieldNode) typ() Type {
+	if n.fieldType == MAP && len(n.key) != 0 {
+		return STRING
+	}
+	return n.fieldType
+}
+
+func (n *fieldNode) eval(record Record) value {
+	v := newValue(n.fieldType, record[n.name])
+	if n.fieldType != MAP || len(n.key) == 0 {
+		return v
+	}
+	if entry, ok := v.m[n.key]; ok {
+		return value{typ: STRING, str: entry}
+	}
+	return nullValue(STRING)
+}
+
+type literalNode struct {
+	value value
+}
+
+func (n *literalNode) typ() Type {
+	return n.value.typ
+}
+
+func (n *literalNode) eval(record Record) value {
+	return n.value
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) typ() Type {
+	return BOOL
+}
+
+func (n *notNode) eval(record Record) value {
+	return value{typ: BOOL, b: !n.operand.eval(record).truthy()}
+}
+
+type logicalNode struct {
+	operator    string
+	left, right node
+}
+
+func (n *logicalNode) typ() Type {
+	return BOOL
+}
+
+func (n *logicalNode) eval(record Record) value {
+	left := n.left.eval(record).truthy()
+	if n.operator == "&&" {
+		return value{typ: BOOL, b: left && n.right.eval(record).truthy()}
+	}
+	return value{typ: BOOL, b: left || n.right.eval(record).truthy()}
+}
+
+type compareNode struct {
+	operator    string
+	left, right node
+	regex       *regexp.Regexp
+}
+
+func (n *compareNode) typ() Type {
+	return BOOL
+}
+
+func (n *compareNode) eval(record Record) value {
+	left, right := n.left.eval(record), n.right.eval(record)
+	if left.null || right.null {
+		return value{typ: BOOL, b: n.operator == "!=" || n.operator == "!~"}
+	}
+	var result bool
+	switch n.operator {
+	case "=~":
+		result = n.regex.MatchString(left.str)
+	case "!~":
+		result = !n.regex.MatchString(left.str)
+	case "==":
+		result = left.compare(right) == 0
+	case "!=":
+		result = left.compare(right) != 0
+	case "<":
+		result = left.compare(right) < 0
+	case "<=":
+		result = left.compare(right) <= 0
+	case ">":
+		result = left.compare(right) > 0
+	case ">=":
+		result = left.compare(right) >= 0
+	}
+	return value{typ: BOOL, b: result}
+}
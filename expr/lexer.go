@@ -0,0 +1,127 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenDuration
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenDot
+)
+
+var (
+	// longest operators first so <= isn't read as <
+	operators = []string{"&&", "||", "==", "!=", "=~", "!~", "<=", ">=", "<", ">", "!", "+", "-"}
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q at %d", t.text, t.pos+1)
+}
+
+// tokenize split expression into tokens, string literals are unquoted
+func tokenize(source string) ([]token, error) {
+	tokens := []token{}
+	i := 0
+	for i < len(source) {
+		c := rune(source[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(source) && rune(source[end]) != c {
+				if source[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(source) {
+				return nil, fmt.Errorf("unterminated string at %d", i+1)
+			}
+			text := source[i : end+1]
+			value, err := unquote(text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s at %d", text, i+1)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, value: value, pos: i})
+			i = end + 1
+		case unicode.IsDigit(c):
+			end := i
+			for end < len(source) && (unicode.IsDigit(rune(source[end])) || source[end] == '.') {
+				end++
+			}
+			kind := tokenNumber
+			// number followed by unit is a duration e.g. 30d, 12h
+			if end < len(source) && unicode.IsLetter(rune(source[end])) {
+				kind = tokenDuration
+				for end < len(source) && (unicode.IsLetter(rune(source[end])) || unicode.IsDigit(rune(source[end]))) {
+					end++
+				}
+			}
+			tokens = append(tokens, token{kind: kind, text: source[i:end], value: source[i:end], pos: i})
+			i = end
+		case unicode.IsLetter(c) || c == '_':
+			end := i
+			for end < len(source) && isIdentRune(rune(source[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: source[i:end], value: source[i:end], pos: i})
+			i = end
+		case c == '(' || c == ')' || c == '[' || c == ']' || c == '.':
+			kind := map[rune]tokenKind{'(': tokenLParen, ')': tokenRParen, '[': tokenLBracket, ']': tokenRBracket, '.': tokenDot}[c]
+			tokens = append(tokens, token{kind: kind, text: string(c), value: string(c), pos: i})
+			i++
+		default:
+			matched := false
+			for _, operator := range operators {
+				if strings.HasPrefix(source[i:], operator) {
+					tokens = append(tokens, token{kind: tokenOperator, text: operator, value: operator, pos: i})
+					i += len(operator)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at %d", c, i+1)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(source)}), nil
+}
+
+// '-' isn't allowed so now-30d is read as subtraction, use tags["cost-center"] for such keys
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// unquote double quoted string with go escapes, single quoted string is raw so regex like '\d+' needs no escaping
+func unquote(text string) (string, error) {
+	if text[0] == '\'' {
+		return strings.ReplaceAll(text[1:len(text)-1], `\'`, `'`), nil
+	}
+	return strconv.Unquote(text)
+}
@@ -0,0 +1,271 @@
+package expr
+
+import (
+	ctltime "cloudctl/time"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	comparisonOperators = map[string]bool{"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "=~": true, "!~": true}
+)
+
+// Expression is a compiled --where expression e.g. state == "running" && launchTime < now-30d && tags.env =~ "prod.*"
+type Expression struct {
+	source string
+	root   node
+}
+
+// Compile parse expression & type check it against schema, relative times like now-30d are resolved against now
+func Compile(source string, schema Schema, now time.Time) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, invalidExpression(source, err)
+	}
+	p := &parser{tokens: tokens, schema: schema, now: now}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEOF {
+		err = fmt.Errorf("unexpected %s", p.peek())
+	}
+	if err != nil {
+		return nil, invalidExpression(source, err)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// Match evaluate expression over record
+func (e *Expression) Match(record Record) bool {
+	return e.root.eval(record).truthy()
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+func invalidExpression(source string, err error) error {
+	return fmt.Errorf("invalid --where expression %q: %w", source, err)
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	schema Schema
+	now    time.Time
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(operator string) bool {
+	t := p.peek()
+	return t.kind == tokenOperator && t.value == operator
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{operator: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("&&") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{operator: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("!") {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != tokenOperator || !comparisonOperators[t.value] {
+		return left, nil
+	}
+	p.next()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return p.newComparison(t.value, left, right)
+}
+
+// newComparison type check operands, string literal compared with time is parsed as time
+func (p *parser) newComparison(operator string, left, right node) (node, error) {
+	left, right, err := p.coerceTime(left, right)
+	if err != nil {
+		return nil, err
+	}
+	if operator == "=~" || operator == "!~" {
+		literal, ok := right.(*literalNode)
+		if left.typ() != STRING || !ok || literal.typ() != STRING {
+			return nil, fmt.Errorf("operator %s needs string field on left and regex string on right", operator)
+		}
+		regex, err := regexp.Compile(literal.value.str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %w", literal.value.str, err)
+		}
+		return &compareNode{operator: operator, left: left, right: right, regex: regex}, nil
+	}
+	if left.typ() != right.typ() || left.typ() == MAP {
+		return nil, typeError(operator, left.typ(), right.typ())
+	}
+	if left.typ() == BOOL && operator != "==" && operator != "!=" {
+		return nil, typeError(operator, left.typ(), right.typ())
+	}
+	return &compareNode{operator: operator, left: left, right: right}, nil
+}
+
+func (p *parser) coerceTime(left, right node) (node, node, error) {
+	toTime := func(n node) (node, error) {
+		literal, ok := n.(*literalNode)
+		if !ok || literal.typ() != STRING {
+			return n, nil
+		}
+		t, err := ctltime.ParseTime(literal.value.str, p.now)
+		if err != nil {
+			return nil, err
+		}
+		return &literalNode{value: value{typ: TIME, time: t}}, nil
+	}
+	var err error
+	if left.typ() == TIME && right.typ() == STRING {
+		right, err = toTime(right)
+	} else if right.typ() == TIME && left.typ() == STRING {
+		left, err = toTime(left)
+	}
+	return left, right, err
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenRParen {
+			return nil, fmt.Errorf("missing ) for ( at %d", t.pos+1)
+		}
+		return n, nil
+	case tokenString:
+		return &literalNode{value: value{typ: STRING, str: t.value}}, nil
+	case tokenNumber:
+		number, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", t)
+		}
+		return &literalNode{value: value{typ: NUMBER, num: number}}, nil
+	case tokenOperator:
+		// negative number
+		if t.value == "-" && p.peek().kind == tokenNumber {
+			number, err := strconv.ParseFloat(p.next().value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %s", t)
+			}
+			return &literalNode{value: value{typ: NUMBER, num: -number}}, nil
+		}
+	case tokenDuration:
+		return nil, fmt.Errorf("duration %s must be used as now-%s", t, t.value)
+	case tokenIdent:
+		switch strings.ToLower(t.value) {
+		case "true", "false":
+			return &literalNode{value: value{typ: BOOL, b: strings.EqualFold(t.value, "true")}}, nil
+		case "now":
+			return p.parseNow()
+		}
+		return p.parseField(t)
+	}
+	return nil, fmt.Errorf("unexpected %s", t)
+}
+
+// parseNow parse now, now-30d or now+1h
+func (p *parser) parseNow() (node, error) {
+	now := p.now
+	if p.isOperator("-") || p.isOperator("+") {
+		sign := p.next().value
+		t := p.next()
+		if t.kind != tokenDuration {
+			return nil, fmt.Errorf("expected duration like 30d after now%s, got %s", sign, t)
+		}
+		d, err := ctltime.ParseDuration(t.value)
+		if err != nil {
+			return nil, err
+		}
+		if sign == "-" {
+			d = -d
+		}
+		now = now.Add(d)
+	}
+	return &literalNode{value: value{typ: TIME, time: now}}, nil
+}
+
+func (p *parser) parseField(t token) (node, error) {
+	name, fieldType, ok := p.schema.lookup(t.value)
+	if !ok {
+		return nil, fmt.Errorf("unknown field %s, supported fields [%s]", t, p.schema.fields())
+	}
+	field := &fieldNode{name: name, fieldType: fieldType}
+	switch p.peek().kind {
+	case tokenDot:
+		p.next()
+		key := p.next()
+		if fieldType != MAP || (key.kind != tokenIdent && key.kind != tokenString) {
+			return nil, fmt.Errorf("unexpected . after %s, only map fields have keys", name)
+		}
+		field.key = key.value
+	case tokenLBracket:
+		p.next()
+		key := p.next()
+		if fieldType != MAP || key.kind != tokenString || p.next().kind != tokenRBracket {
+			return nil, fmt.Errorf("expected %s[\"key\"]", name)
+		}
+		field.key = key.value
+	}
+	return field, nil
+}
@@ -0,0 +1,124 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testNow    = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	testSchema = Schema{
+		"name":       STRING,
+		"state":      STRING,
+		"vcpus":      NUMBER,
+		"encrypted":  BOOL,
+		"launchTime": TIME,
+		"tags":       MAP,
+	}
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		source string
+		// empty when expression is valid, otherwise part of error message
+		err string
+	}{
+		{`state == "running"`, ""},
+		{`State == "running"`, ""},
+		{`launchTime < now-30d`, ""},
+		{`launchTime > now+1h`, ""},
+		{`launchTime < "2024-01-01"`, ""},
+		{`tags.env == "prod"`, ""},
+		{`tags["cost-center"] == "42"`, ""},
+		{`tags.env && !encrypted`, ""},
+		{`vcpus >= -1`, ""},
+		{`(state == "running" || state == "stopped") && name =~ "^web-"`, ""},
+		{`owner == "me"`, "unknown field"},
+		{`vcpus == "2"`, "isn't supported between number and string"},
+		{`encrypted < true`, "isn't supported between bool and bool"},
+		{`tags == "prod"`, "isn't supported between map and string"},
+		{`vcpus =~ "2"`, "needs string field"},
+		{`name =~ "web-["`, "invalid regex"},
+		{`launchTime < now-30`, "expected duration"},
+		{`launchTime < 30d`, "must be used as now-30d"},
+		{`launchTime < "last tuesday"`, "last tuesday"},
+		{`tags["k"`, `expected tags["key"]`},
+		{`tags[k] == "v"`, `expected tags["key"]`},
+		{`name.first == "a"`, "only map fields have keys"},
+		{`(state == "running"`, "missing )"},
+		{`state == "running" state`, "unexpected"},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			_, err := Compile(test.source, testSchema, testNow)
+			if len(test.err) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q", test.err)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Errorf("error %q doesn't contain %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	running, name, launchTime := "running", "web-1", testNow.Add(-40*24*time.Hour)
+	record := Record{
+		"name":       &name,
+		"state":      &running,
+		"vcpus":      int64(2),
+		"encrypted":  false,
+		"launchTime": &launchTime,
+		"tags":       map[string]string{"env": "prod", "cost-center": "42"},
+	}
+	// every field is missing or nil
+	var nilName *string
+	empty := Record{"name": nilName, "launchTime": (*time.Time)(nil)}
+	tests := []struct {
+		source string
+		record Record
+		want   bool
+	}{
+		{`state == "running"`, record, true},
+		{`state != "running"`, record, false},
+		{`state == "stopped" || vcpus == 2`, record, true},
+		{`name =~ "^web-"`, record, true},
+		{`name !~ "^web-"`, record, false},
+		{`name !~ "^db-"`, record, true},
+		{`launchTime < now-30d`, record, true},
+		{`launchTime < now-60d`, record, false},
+		{`!encrypted && vcpus >= 2`, record, true},
+		{`tags.env == "prod"`, record, true},
+		{`tags["cost-center"] == "42"`, record, true},
+		{`tags.team == "core"`, record, false},
+		{`tags.team != "core"`, record, true},
+		{`tags.team`, record, false},
+		{`tags.env`, record, true},
+		{`name == "web-1"`, empty, false},
+		{`name != "web-1"`, empty, true},
+		{`name =~ "web"`, empty, false},
+		{`name !~ "web"`, empty, true},
+		{`vcpus < 4`, empty, false},
+		{`launchTime < now`, empty, false},
+		{`!(launchTime < now)`, empty, true},
+		{`tags.env != "prod"`, empty, true},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			expression, err := Compile(test.source, testSchema, testNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := expression.Match(test.record); got != test.want {
+				t.Errorf("Match() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
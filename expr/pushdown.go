@@ -0,0 +1,64 @@
+package expr
+
+// Equality is a condition every match satisfies, field equals one of values, so it can be pushed down to api filters
+type Equality struct {
+	Field string
+	// key of map field e.g. env of tags.env, empty for other fields
+	Key    string
+	Values []string
+}
+
+// Equalities return field == value conditions joined by && at top level, conditions on same field joined by || are merged
+// e.g. (state == "running" || state == "stopped") && tags.env == "prod" return state in [running, stopped] & tags.env in [prod].
+// Matches of api filters built from these are a superset of matches of whole expression, so expression must still be evaluated.
+func (e *Expression) Equalities() []*Equality {
+	equalities := []*Equality{}
+	for _, conjunct := range conjuncts(e.root) {
+		if equality := disjunctEquality(conjunct); equality != nil {
+			equalities = append(equalities, equality)
+		}
+	}
+	return equalities
+}
+
+func conjuncts(n node) []node {
+	if logical, ok := n.(*logicalNode); ok && logical.operator == "&&" {
+		return append(conjuncts(logical.left), conjuncts(logical.right)...)
+	}
+	return []node{n}
+}
+
+// disjunctEquality return equality if n is field == value or several of them on the same field joined by ||
+func disjunctEquality(n node) *Equality {
+	if logical, ok := n.(*logicalNode); ok && logical.operator == "||" {
+		left, right := disjunctEquality(logical.left), disjunctEquality(logical.right)
+		if left == nil || right == nil || left.Field != right.Field || left.Key != right.Key {
+			return nil
+		}
+		return &Equality{Field: left.Field, Key: left.Key, Values: append(left.Values, right.Values...)}
+	}
+	compare, ok := n.(*compareNode)
+	if !ok || compare.operator != "==" {
+		return nil
+	}
+	field, literal := fieldAndLiteral(compare.left, compare.right)
+	if field == nil {
+		field, literal = fieldAndLiteral(compare.right, compare.left)
+	}
+	if field == nil || literal.typ() == TIME {
+		return nil
+	}
+	return &Equality{Field: field.name, Key: field.key, Values: []string{literal.value.String()}}
+}
+
+func fieldAndLiteral(a, b node) (*fieldNode, *literalNode) {
+	field, ok := a.(*fieldNode)
+	if !ok {
+		return nil, nil
+	}
+	literal, ok := b.(*literalNode)
+	if !ok {
+		return nil, nil
+	}
+	return field, literal
+}
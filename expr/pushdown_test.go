@@ -0,0 +1,54 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEqualities(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []*Equality
+	}{
+		{`state == "running"`, []*Equality{{Field: "state", Values: []string{"running"}}}},
+		{`"running" == state`, []*Equality{{Field: "state", Values: []string{"running"}}}},
+		{
+			`(state == "running" || state == "stopped") && tags.env == "prod"`,
+			[]*Equality{
+				{Field: "state", Values: []string{"running", "stopped"}},
+				{Field: "tags", Key: "env", Values: []string{"prod"}},
+			},
+		},
+		{
+			`state == "running" || state == "stopped" || state == "pending"`,
+			[]*Equality{{Field: "state", Values: []string{"running", "stopped", "pending"}}},
+		},
+		{`vcpus == 2 && encrypted == true`, []*Equality{{Field: "vcpus", Values: []string{"2"}}, {Field: "encrypted", Values: []string{"true"}}}},
+		{`state == "running" || name == "web"`, []*Equality{}},
+		{`tags.env == "prod" || tags.team == "core"`, []*Equality{}},
+		{`state == "running" || vcpus > 2`, []*Equality{}},
+		{`state != "running"`, []*Equality{}},
+		{`!(state == "running")`, []*Equality{}},
+		{`name =~ "web" && state == "running"`, []*Equality{{Field: "state", Values: []string{"running"}}}},
+		{`launchTime == "2024-01-01"`, []*Equality{}},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			expression, err := Compile(test.source, testSchema, testNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := expression.Equalities(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Equalities() = %v, want %v", format(got), format(test.want))
+			}
+		})
+	}
+}
+
+func format(equalities []*Equality) []Equality {
+	values := []Equality{}
+	for _, equality := range equalities {
+		values = append(values, *equality)
+	}
+	return values
+}
@@ -0,0 +1,185 @@
+package expr
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type of a field, operands are type checked when expression is compiled
+type Type int
+
+const (
+	STRING Type = iota
+	NUMBER
+	TIME
+	BOOL
+	// MAP is string to string map e.g. tags, its values are accessed as tags.env or tags["cost-center"]
+	MAP
+)
+
+var (
+	typeNames = map[Type]string{
+		STRING: "string",
+		NUMBER: "number",
+		TIME:   "time",
+		BOOL:   "bool",
+		MAP:    "map",
+	}
+)
+
+func (t Type) String() string {
+	return typeNames[t]
+}
+
+// Schema is field name to type of a resource
+type Schema map[string]Type
+
+// lookup find field ignoring case, so launchtime and launchTime are the same field
+func (s Schema) lookup(name string) (string, Type, bool) {
+	for field, t := range s {
+		if strings.EqualFold(field, name) {
+			return field, t, true
+		}
+	}
+	return "", 0, false
+}
+
+func (s Schema) fields() string {
+	fields := []string{}
+	for field, t := range s {
+		if t == MAP {
+			field += ".<key>"
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
+
+// Record is field values of a resource, missing or nil field never matches a comparison except != and !~
+// values can be string, int, int64, float64, bool, time.Time, *time.Time or map[string]string
+type Record map[string]interface{}
+
+type value struct {
+	typ  Type
+	null bool
+	str  string
+	num  float64
+	time time.Time
+	b    bool
+	m    map[string]string
+}
+
+func nullValue(t Type) value {
+	return value{typ: t, null: true}
+}
+
+// newValue convert record value into typed value, nil pointers are null
+func newValue(t Type, v interface{}) value {
+	switch o := v.(type) {
+	case nil:
+		return nullValue(t)
+	case string:
+		return value{typ: STRING, str: o}
+	case *string:
+		if o == nil {
+			return nullValue(t)
+		}
+		return value{typ: STRING, str: *o}
+	case int:
+		return value{typ: NUMBER, num: float64(o)}
+	case int64:
+		return value{typ: NUMBER, num: float64(o)}
+	case *int64:
+		if o == nil {
+			return nullValue(t)
+		}
+		return value{typ: NUMBER, num: float64(*o)}
+	case float64:
+		return value{typ: NUMBER, num: o}
+	case bool:
+		return value{typ: BOOL, b: o}
+	case *bool:
+		if o == nil {
+			return nullValue(t)
+		}
+		return value{typ: BOOL, b: *o}
+	case time.Time:
+		return value{typ: TIME, time: o}
+	case *time.Time:
+		if o == nil {
+			return nullValue(t)
+		}
+		return value{typ: TIME, time: *o}
+	case map[string]string:
+		return value{typ: MAP, m: o}
+	}
+	return nullValue(t)
+}
+
+// String render value for api filter, time isn't pushed down
+func (v value) String() string {
+	switch v.typ {
+	case NUMBER:
+		return strconv.FormatFloat(v.num, 'f', -1, 64)
+	case BOOL:
+		return strconv.FormatBool(v.b)
+	case TIME:
+		return v.time.Format(time.RFC3339)
+	}
+	return v.str
+}
+
+// compare return -1, 0, 1, values must be of same type
+func (v value) compare(other value) int {
+	switch v.typ {
+	case NUMBER:
+		if v.num < other.num {
+			return -1
+		} else if v.num > other.num {
+			return 1
+		}
+		return 0
+	case TIME:
+		if v.time.Before(other.time) {
+			return -1
+		} else if v.time.After(other.time) {
+			return 1
+		}
+		return 0
+	case BOOL:
+		if v.b == other.b {
+			return 0
+		}
+		if !v.b {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(v.str, other.str)
+}
+
+// truthy is used when a non bool operand is used as condition e.g. tags.env means env tag is present
+func (v value) truthy() bool {
+	if v.null {
+		return false
+	}
+	switch v.typ {
+	case BOOL:
+		return v.b
+	case STRING:
+		return len(v.str) != 0
+	case NUMBER:
+		return v.num != 0
+	case MAP:
+		return len(v.m) != 0
+	}
+	return true
+}
+
+func typeError(operator string, left, right Type) error {
+	return fmt.Errorf("operator %s isn't supported between %s and %s", operator, left, right)
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"cloudctl/provider/aws"
 	"cloudctl/provider/aws/cli"
 	"cloudctl/provider/aws/cli/globals"
+	ctltime "cloudctl/time"
+	"cloudctl/viewer"
 
 	"github.com/alecthomas/kong"
 )
@@ -27,7 +30,12 @@ func main() {
 		kong.Vars{
 			"version": "0.0.1",
 		})
-	err := ctx.Run(&cli.CLIFlag)
+	customColumns, err := viewer.ParseCustomColumns(cli.CustomColumns)
+	ctx.FatalIfErrorf(err)
+	viewer.SetColumnOptions(cli.Columns, customColumns, cli.Wide)
+	ctx.FatalIfErrorf(cli.ResolveTimezone(aws.ResolveProfile(cli.Profile)))
+	ctx.FatalIfErrorf(ctltime.SetFormat(cli.TimeFormat))
+	err = ctx.Run(&cli.CLIFlag)
 	ctx.FatalIfErrorf(err)
 
 }
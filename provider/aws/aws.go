@@ -1,15 +1,22 @@
 package aws
 
 import (
+	ctlconfig "cloudctl/config"
+	"cloudctl/provider/aws/cli/globals"
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"os"
+	"sync"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go/service/ssm"
 	"gopkg.in/ini.v1"
 
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -19,6 +26,10 @@ import (
 
 const (
 	DEFAULT_REGION = "eu-west-1"
+
+	endpoint_url_key  = "endpoint_url"
+	s3_path_style_key = "s3_path_style"
+	no_verify_ssl_key = "no_verify_ssl"
 )
 
 var (
@@ -30,19 +41,65 @@ type Client struct {
 	EC2          *ec2.EC2
 	S3           *s3.S3
 	S3Downloader *s3manager.Downloader
+	SSM          *ssm.SSM
+	AutoScaling  *autoscaling.AutoScaling
+	session      *session.Session
+	profile      string
+	// clients of other regions share the session configuration, created on demand
+	regionClients *sync.Map
 }
 
-func NewClient(profile, region string, debug bool) (client *Client) {
-	session, _ := newSession(profile, region, debug)
-	client = &Client{
-		EC2:          ec2.New(session),
-		S3:           s3.New(session),
-		S3Downloader: s3manager.NewDownloader(session),
-	}
+type endpointSetting struct {
+	url         string
+	s3PathStyle bool
+	noVerifySSL bool
+}
+
+func NewClient(flag *globals.CLIFlag) (client *Client) {
+	session, profile := newSession(flag)
+	client = newClient(session, profile, &sync.Map{})
 	return
 }
 
-func newSession(profile, region string, debug bool) (sess *session.Session, err error) {
+func newClient(session *session.Session, profile string, regionClients *sync.Map) *Client {
+	return &Client{
+		EC2:           ec2.New(session),
+		S3:            s3.New(session),
+		S3Downloader:  s3manager.NewDownloader(session),
+		SSM:           ssm.New(session),
+		AutoScaling:   autoscaling.New(session),
+		session:       session,
+		profile:       profile,
+		regionClients: regionClients,
+	}
+}
+
+// Region return the region client is configured with
+func (c *Client) Region() string {
+	return aws.StringValue(c.session.Config.Region)
+}
+
+// Profile return the resolved profile, empty if credentials come from environment
+func (c *Client) Profile() string {
+	return c.profile
+}
+
+// ForRegion return a client for the region with the same credentials & endpoint setting, clients are cached per region
+func (c *Client) ForRegion(region string) *Client {
+	if len(region) == 0 || region == c.Region() {
+		return c
+	}
+	if client, ok := c.regionClients.Load(region); ok {
+		return client.(*Client)
+	}
+	client := newClient(c.session.Copy(&aws.Config{Region: aws.String(region)}), c.profile, c.regionClients)
+	actual, _ := c.regionClients.LoadOrStore(region, client)
+	return actual.(*Client)
+}
+
+// newSession return session & profile it is created with
+func newSession(flag *globals.CLIFlag) (sess *session.Session, p string) {
+	profile, region, debug := flag.Profile, flag.Region, flag.Debug
 
 	defaultConfig := defaults.Get().Config
 
@@ -51,8 +108,7 @@ func newSession(profile, region string, debug bool) (sess *session.Session, err
 		logLevel = aws.LogLevel(aws.LogDebugWithRequestRetries | aws.LogDebugWithRequestErrors)
 	}
 
-	// fetch profile from env `see:env_profile` if not provide via command
-	p := getEnv(profile, env_profile)
+	p = ResolveProfile(profile)
 	// fetch region from env `see:env_region` if not provide via command
 	r := getEnv(region, env_region)
 
@@ -66,13 +122,52 @@ func newSession(profile, region string, debug bool) (sess *session.Session, err
 
 	cred := newCred(&p)
 
-	config := defaultConfig.WithCredentials(cred).WithRegion(r).WithLogLevel(*logLevel)
+	awsConfig := defaultConfig.WithCredentials(cred).WithRegion(r).WithLogLevel(*logLevel)
 
-	session.NewSession(config)
-	sess = session.Must(session.NewSession(config))
+	// profile can be changed during credential prompt, so resolve endpoint after it
+	endpoint := newEndpointSetting(flag, p)
+	if len(endpoint.url) != 0 {
+		awsConfig = awsConfig.WithEndpoint(endpoint.url)
+	}
+	if endpoint.s3PathStyle {
+		awsConfig = awsConfig.WithS3ForcePathStyle(true)
+	}
+	if endpoint.noVerifySSL {
+		// keep proxy, timeouts & http2 of default transport
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+		awsConfig = awsConfig.WithHTTPClient(&http.Client{Transport: transport})
+	}
+
+	sess = session.Must(session.NewSession(awsConfig))
 	return
 }
 
+// command flags take precedence over the profile settings in config file
+func newEndpointSetting(flag *globals.CLIFlag, profile string) *endpointSetting {
+	cfg := ctlconfig.Load()
+	setting := &endpointSetting{
+		url: flag.EndpointURL,
+	}
+	if len(setting.url) == 0 {
+		setting.url = cfg.Value(profile, endpoint_url_key)
+	}
+	if flag.S3PathStyle != nil {
+		setting.s3PathStyle = *flag.S3PathStyle
+	} else if v := cfg.Bool(profile, s3_path_style_key); v != nil {
+		setting.s3PathStyle = *v
+	}
+	if flag.NoVerifySSL != nil {
+		setting.noVerifySSL = *flag.NoVerifySSL
+	} else if v := cfg.Bool(profile, no_verify_ssl_key); v != nil {
+		setting.noVerifySSL = *v
+	}
+	return setting
+}
+
 func newCred(profile *string) (cred *credentials.Credentials) {
 	credProviders := []credentials.Provider{}
 
@@ -126,6 +221,11 @@ func fetchConfiguredProfiles() ([]string, error) {
 	return nil, err
 }
 
+// ResolveProfile return profile of command, or from env `see:env_profile` if not provided, as sessions are created with
+func ResolveProfile(profile string) string {
+	return getEnv(profile, env_profile)
+}
+
 func getEnv(value string, keys []string) string {
 	if len(value) == 0 {
 		for _, key := range keys {
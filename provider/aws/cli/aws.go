@@ -3,6 +3,9 @@ package cli
 import "cloudctl/provider/aws/cli/services"
 
 type AWSCmd struct {
-	S3  services.S3Command  `name:"s3" cmd:"" help:"Operation on S3 buckets"`
-	EC2 services.EC2Command `name:"ec2" cmd:"" help:"Operation on ec2"`
+	S3     services.S3Command     `name:"s3" cmd:"" help:"Operation on S3 buckets"`
+	EC2    services.EC2Command    `name:"ec2" cmd:"" help:"Operation on ec2"`
+	VPC    services.VPCCommand    `name:"vpc" cmd:"" help:"Operation on vpcs"`
+	Subnet services.SubnetCommand `name:"subnet" cmd:"" help:"Operation on subnets"`
+	ASG    services.ASGCommand    `name:"asg" cmd:"" help:"Operation on auto scaling groups"`
 }
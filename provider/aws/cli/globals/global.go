@@ -1,8 +1,34 @@
 package globals
 
+import (
+	ctlconfig "cloudctl/config"
+	ctltime "cloudctl/time"
+)
+
+const (
+	tz_key = "tz"
+)
+
 type CLIFlag struct {
-	Profile           string `name:"profile" short:"p" help:"Set AWS profile" default:""`
-	Region            string `name:"region" short:"r" help:"Set AWS Region" default:""`
-	Debug             bool   `name:"debug" short:"d" help:"Allow debug" negatable:""`
-	TZShortIdentifier string `name:"tz" help:"Configured Timezne in aws output, supported input [utc,los_angeles,tokyo]" default:"utc" required:""`
+	Profile           string   `name:"profile" short:"p" help:"Set AWS profile" default:""`
+	Region            string   `name:"region" short:"r" help:"Set AWS Region" default:""`
+	Debug             bool     `name:"debug" short:"d" help:"Allow debug" negatable:""`
+	TZShortIdentifier string   `name:"tz" help:"Timezone of aws output and time filters, IANA name (for example, Europe/Paris), local or [utc,los_angeles,tokyo], can be set as 'tz' in config file | Default is utc" default:""`
+	TimeFormat        string   `name:"time-format" enum:",iso,rfc1123,relative,iso+relative,rfc1123+relative" help:"Format of times in tables, supported input [iso,rfc1123,relative,iso+relative,rfc1123+relative], relative shows values like 3d ago" default:""`
+	EndpointURL       string   `name:"endpoint-url" help:"Override AWS service endpoint url (for example, MinIO or LocalStack), can be set per profile as 'endpoint_url' in config file" default:""`
+	S3PathStyle       *bool    `name:"s3-path-style" help:"Use path-style addressing for S3 requests, can be set per profile as 's3_path_style' in config file"`
+	NoVerifySSL       *bool    `name:"no-verify-ssl" help:"Disable SSL certificate verification, can be set per profile as 'no_verify_ssl' in config file"`
+	Columns           []string `name:"columns" help:"Show only these columns of every table, for example id,type,privateIp" default:""`
+	CustomColumns     string   `name:"custom-columns" help:"Show custom columns in NAME:.field format, nested keys are selected with NAME:.field.key, for example ID:.id,IP:.privateIp,ENV:.tags.env" default:""`
+	Wide              bool     `name:"wide" help:"Show all columns, some columns are hidden by default to fit in terminal"`
+}
+
+// ResolveTimezone fallback to tz of profile in config file when --tz isn't set, profile is resolved from flag & env
+// like the session, timezone is validated so commands can use GetTZ
+func (flag *CLIFlag) ResolveTimezone(profile string) error {
+	if len(flag.TZShortIdentifier) == 0 {
+		flag.TZShortIdentifier = ctlconfig.Load().Value(profile, tz_key)
+	}
+	_, err := ctltime.LoadTZ(flag.TZShortIdentifier)
+	return err
 }
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"cloudctl/provider/aws/cli/globals"
+	"cloudctl/provider/aws/services/autoscaling"
+	"fmt"
+)
+
+type autoScalingGroupListCmd struct {
+	Names []string `name:"name" help:"Return auto scaling groups whose name contains value(s)" default:""`
+	Where *string  `name:"where" help:"Filter with expression over auto scaling group fields, for example, desired != instances || tags.team == 'web' | Unknown field error lists supported fields"`
+}
+
+type autoScalingGroupDefinitionCmd struct {
+	Name       string `name:"name" arg:"required" help:"Auto scaling group name"`
+	Activities int64  `name:"activities" help:"Number of recent scaling activities to show, between 1 and 100" default:"10"`
+}
+
+type launchConfigurationListCmd struct {
+	Names  []string `name:"name" help:"Return launch configurations whose name contains value(s)" default:""`
+	Unused bool     `name:"unused" help:"Return only launch configurations which aren't used by any auto scaling group"`
+}
+
+type launchConfigurationCmd struct {
+	List launchConfigurationListCmd `name:"ls" cmd:"" help:"List launch configurations with auto scaling groups using them"`
+}
+
+type ASGCommand struct {
+	List          autoScalingGroupListCmd       `name:"ls" cmd:"" help:"List auto scaling groups"`
+	Definition    autoScalingGroupDefinitionCmd `name:"def" cmd:"" help:"Get auto scaling group definition with instances, suspended processes and recent scaling activities"`
+	LaunchConfigs launchConfigurationCmd        `name:"launch-configs" cmd:"" help:"Operation on launch configurations"`
+}
+
+func (cmd *autoScalingGroupListCmd) Run(globals *globals.CLIFlag) error {
+	where, err := compileWhere(cmd.Where, autoscaling.AutoScalingGroupSchema, globals)
+	if err != nil {
+		return err
+	}
+	filter := autoscaling.NewAutoScalingGroupFilter(
+		autoscaling.WithAutoScalingGroupNames(cmd.Names),
+		autoscaling.WithAutoScalingGroupWhere(where),
+	)
+	icmd := autoscaling.NewAutoScalingGroupListCommandExecutor(globals, filter)
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *autoScalingGroupDefinitionCmd) Run(globals *globals.CLIFlag) error {
+	// DescribeScalingActivities returns at most 100 records
+	if cmd.Activities < 1 || cmd.Activities > 100 {
+		return fmt.Errorf("--activities must be between 1 and 100, got %d", cmd.Activities)
+	}
+	icmd := autoscaling.NewAutoScalingGroupDescribeCommandExecutor(globals, cmd.Name, cmd.Activities)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *launchConfigurationListCmd) Run(globals *globals.CLIFlag) error {
+	filter := autoscaling.NewLaunchConfigurationFilter(
+		autoscaling.WithLaunchConfigurationNames(cmd.Names),
+		autoscaling.WithUnusedLaunchConfigurations(cmd.Unused),
+	)
+	icmd := autoscaling.NewLaunchConfigurationListCommandExecutor(globals, filter)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"cloudctl/provider/aws/cli/globals"
 	"cloudctl/provider/aws/services/ec2"
+	"fmt"
 	"log"
 )
 
@@ -12,17 +13,231 @@ type eC2ListCmd struct {
 	AvailabilityZones []string `name:"az" help:"Return instance list of specific availability zone(s)" default:""`
 	VpcIds            []string `name:"vpc" help:"Return instance list of specific vpcId(s)" default:""`
 	SubnetIds         []string `name:"subnet" help:"Return instance list of specific subnet(s)" default:""`
+	AsgNames          []string `name:"asg" help:"Return instance list of specific auto scaling group(s)" default:""`
+	Lifecycles        []string `name:"lifecycle" help:"Return instance list of specific lifecycle(s) | values (spot | on-demand | scheduled | capacity-block)" default:""`
 	HasPublicIp       *bool    `name:"has-public-ip" help:"Return instance list which have public ip associate"`
-	LaunchAtString    *string  `name:"launchat" help:"The time when the instance was launched, in the ISO 8601 format in the UTC time zone (YYYY-MM-DDThh:mm:ss.sssZ), for example, 2021-09-29T11:04:43.305Z. You can use a wildcard (*), for example, 2021-09-29T*, which matches an entire day."`
+	ShowNames         bool     `name:"show-names" help:"Show Name tag of vpc and subnet along with their id"`
+	ShowStatus        bool     `name:"show-status" help:"Show status checks, scheduled events and uptime of instances"`
+	Impaired          bool     `name:"impaired" help:"Return instance list which have impaired system or instance status check"`
+	GroupBy           string   `name:"group-by" help:"Group instances by, supported input [state,type,az,vpc,subnet,tag:<key>,none]" default:"state"`
+	Summary           bool     `name:"summary" help:"Show instance count, vCPU and memory per group instead of instances"`
+	SortBy            string   `name:"sort-by" enum:",launch,type,id" default:"" help:"Sort instances within group by, supported input [launch,type,id]"`
+	Order             string   `name:"order" enum:"asc,desc" default:"asc" help:"Sort order, supported input [asc,desc]"`
+	Where             *string  `name:"where" help:"Filter with expression over instance fields, for example, state == 'running' && launchTime < now-30d && tags.env =~ 'prod.*' | Unknown field error lists supported fields"`
+	LaunchAtString    *string  `name:"launchat" help:"Deprecated, use --since/--until | The time when the instance was launched, in the ISO 8601 format in the UTC time zone (YYYY-MM-DDThh:mm:ss.sssZ), for example, 2021-09-29T11:04:43.305Z. You can use a wildcard (*), for example, 2021-09-29T*, which matches an entire day."`
+	timeRangeFlags
 }
 
 type instanceDefinitionCmd struct {
 	Id string `name:"name" arg:"required"`
 }
 
+type securityGroupListCmd struct {
+	VpcIds     []string          `name:"vpc" help:"Return security groups of specific vpcId(s)" default:""`
+	GroupNames []string          `name:"name" help:"Return security groups of specific name(s), You can use a wildcard (*), for example, web-*" default:""`
+	Tags       map[string]string `name:"tag" help:"Return security groups with tag(s) in key=value format, use key= to match tag key only"`
+}
+
+type securityGroupDefinitionCmd struct {
+	Id string `name:"id" arg:"required" help:"Security group id"`
+}
+
+type securityGroupAuditCmd struct {
+	Regions    []string `name:"regions" help:"Audit security groups of specific region(s), Default is configured region" default:""`
+	AllRegions bool     `name:"all-regions" help:"Audit security groups of all enabled regions"`
+	Egress     bool     `name:"egress" help:"Report unrestricted egress to internet as well, it is allowed by default rule of every security group"`
+}
+
+type securityGroupCmd struct {
+	List       securityGroupListCmd       `name:"ls" cmd:"" help:"List security groups"`
+	Definition securityGroupDefinitionCmd `name:"def" cmd:"" help:"Get security group definition with its usage"`
+	Audit      securityGroupAuditCmd      `name:"audit" cmd:"" help:"Audit security groups for exposure to internet, exit with error on critical finding"`
+}
+
+type reachabilityCmd struct {
+	From     string `name:"from" required:"" help:"Source of traffic, instance id/name, CIDR/IP or security group id"`
+	To       string `name:"to" required:"" help:"Destination instance id/name"`
+	Port     int64  `name:"port" required:"" help:"Destination port"`
+	Protocol string `name:"proto" enum:"tcp,udp,icmp,all" default:"tcp" help:"Protocol, supported input [tcp,udp,icmp,all]"`
+}
+
+type volumeListCmd struct {
+	Unattached        bool     `name:"unattached" help:"Return only volumes which aren't attached to any instance"`
+	Encrypted         *bool    `name:"encrypted" negatable:"" help:"Return only encrypted (--encrypted) or unencrypted (--no-encrypted) volumes"`
+	VolumeTypes       []string `name:"type" help:"Return volumes of specific type(s) | values (standard | io1 | io2 | gp2 | gp3 | sc1 | st1)" default:""`
+	AvailabilityZones []string `name:"az" help:"Return volumes of specific availability zone(s)" default:""`
+	MinSize           int64    `name:"min-size" help:"Return volumes with size greater than or equal to value in GiB"`
+	MaxSize           int64    `name:"max-size" help:"Return volumes with size less than or equal to value in GiB"`
+	Where             *string  `name:"where" help:"Filter with expression over volume fields, for example, size >= 100 && !tags.owner | Unknown field error lists supported fields"`
+}
+
+type volumeCleanupCmd struct {
+	OlderThan   *string `name:"older-than" help:"Report only volumes and snapshots older than duration or created before time, for example 30d, 12w, 2024-01-31 | Required with --delete"`
+	Delete      bool    `name:"delete" help:"Delete orphaned volumes and snapshots after confirmation"`
+	AutoApprove bool    `name:"yes" short:"y" help:"Delete without confirmation"`
+}
+
+type volumeCmd struct {
+	List    volumeListCmd    `name:"ls" cmd:"" help:"List EBS volumes"`
+	Cleanup volumeCleanupCmd `name:"cleanup" cmd:"" help:"Find unattached volumes and snapshots of deleted volumes"`
+}
+
+type snapshotListCmd struct {
+	Owners    []string `name:"owner" help:"Return snapshots of specific owner(s), account id or self | amazon | Default is self" default:""`
+	VolumeIds []string `name:"volume" help:"Return snapshots of specific volumeId(s)" default:""`
+	timeRangeFlags
+	Where *string `name:"where" help:"Filter with expression over snapshot fields, for example, startTime < now-90d && !encrypted | Unknown field error lists supported fields"`
+}
+
+type snapshotCreateCmd struct {
+	Instance          string            `name:"instance" required:"" help:"Instance id or Name tag, snapshot is created for every attached volume"`
+	Description       string            `name:"description" help:"Snapshot description"`
+	Tags              map[string]string `name:"tag" help:"Snapshot tag(s) in key=value format, volume tags are copied as well"`
+	ExcludeBootVolume bool              `name:"exclude-boot-volume" help:"Don't snapshot root volume"`
+}
+
+type snapshotCmd struct {
+	List   snapshotListCmd   `name:"ls" cmd:"" help:"List EBS snapshots"`
+	Create snapshotCreateCmd `name:"create" cmd:"" help:"Create snapshots of instance volumes"`
+}
+
+type imageListCmd struct {
+	Owners        []string `name:"owner" help:"Return images of specific owner(s), account id or self | amazon | aws-marketplace | Default is self" default:""`
+	Names         []string `name:"name" help:"Return images of specific name(s), You can use a wildcard (*), for example, web-*" default:""`
+	Architectures []string `name:"arch" help:"Return images of specific architecture(s) | values (i386 | x86_64 | arm64)" default:""`
+	Where         *string  `name:"where" help:"Filter with expression over image fields, for example, public && creationDate < now-180d | Unknown field error lists supported fields"`
+}
+
+type imageDefinitionCmd struct {
+	Id string `name:"id" arg:"required" help:"Image id"`
+}
+
+type imageUnusedCmd struct {
+	OlderThan   *string `name:"older-than" help:"Report only images older than duration or created before time, for example 90d, 12w, 2024-01-31 | Required with --deregister"`
+	Deregister  bool    `name:"deregister" help:"Deregister unused images and delete their snapshots after confirmation"`
+	AutoApprove bool    `name:"yes" short:"y" help:"Deregister without confirmation"`
+}
+
+type imageCmd struct {
+	List       imageListCmd       `name:"ls" cmd:"" help:"List AMIs"`
+	Definition imageDefinitionCmd `name:"def" cmd:"" help:"Get AMI definition with backing snapshots and instances using it"`
+	Unused     imageUnusedCmd     `name:"unused" cmd:"" help:"Report own AMIs not used by any instance, launch template or launch configuration"`
+}
+
+type elasticIpListCmd struct {
+	Unassociated bool `name:"unassociated" help:"Return only elastic ips which aren't associated, they are charged while idle"`
+}
+
+type elasticIpCmd struct {
+	List elasticIpListCmd `name:"ls" cmd:"" help:"List elastic ips"`
+}
+
+type networkInterfaceListCmd struct {
+	Available      bool     `name:"available" help:"Return only network interfaces which aren't attached, they block subnet deletion"`
+	VpcIds         []string `name:"vpc" help:"Return network interfaces of specific vpcId(s)" default:""`
+	SubnetIds      []string `name:"subnet" help:"Return network interfaces of specific subnet(s)" default:""`
+	InterfaceTypes []string `name:"type" help:"Return network interfaces of specific type(s) (for example, interface, nat_gateway, lambda)" default:""`
+}
+
+type networkInterfaceCmd struct {
+	List networkInterfaceListCmd `name:"ls" cmd:"" help:"List network interfaces"`
+}
+
+type connectCmd struct {
+	Target  string  `name:"instance" arg:"required" help:"Instance id or Name tag"`
+	Method  string  `name:"method" enum:"auto,ssm,ssh" default:"auto" help:"Connect via ssm session manager or ssh, auto prefers ssm when instance is online in ssm, supported input [auto,ssm,ssh]"`
+	Forward *string `name:"forward" help:"Forward local port to host:port reachable from instance in local_port:host:remote_port format, for example, 5432:localhost:5432"`
+	IP      string  `name:"ip" enum:"auto,public,private" default:"auto" help:"Ip used by ssh, auto uses public ip if reachable otherwise private ip, supported input [auto,public,private]"`
+	User    string  `name:"user" short:"l" help:"ssh login user, Default is detected from instance image"`
+	KeyFile string  `name:"key" short:"i" type:"existingfile" help:"ssh private key, Default is ~/.ssh/<key pair name>(.pem) if exists"`
+}
+
+type consoleOutputCmd struct {
+	Target         string `name:"instance" arg:"required" help:"Instance id or Name tag"`
+	Latest         bool   `name:"latest" help:"Return latest console output, supported only on nitro instances"`
+	Tail           int    `name:"tail" help:"Show only last n lines"`
+	ScreenshotFile string `name:"screenshot" help:"Save console screenshot as jpg to file"`
+}
+
+type userDataCmd struct {
+	Target string `name:"instance" arg:"required" help:"Instance id or Name tag"`
+}
+
+type instanceTypeListCmd struct {
+	Types             []string `name:"type" help:"Return specific instance type(s), You can use a wildcard (*), for example, m6i.*" default:""`
+	Architectures     []string `name:"arch" help:"Return instance types supporting architecture(s) | values (i386 | x86_64 | arm64)" default:""`
+	MinVcpus          int64    `name:"min-vcpus" help:"Return instance types with at least n vCPU"`
+	MaxVcpus          int64    `name:"max-vcpus" help:"Return instance types with at most n vCPU"`
+	MinMemory         float64  `name:"min-memory" help:"Return instance types with at least memory in GiB"`
+	MaxMemory         float64  `name:"max-memory" help:"Return instance types with at most memory in GiB"`
+	Network           string   `name:"network" help:"Return instance types whose network performance contains value, for example, 25 Gigabit"`
+	NoGpu             bool     `name:"no-gpu" help:"Return instance types without GPU"`
+	EbsOptimized      bool     `name:"ebs-optimized" help:"Return instance types supporting EBS optimization"`
+	CurrentGeneration bool     `name:"current-generation" help:"Return only current generation instance types"`
+}
+
+type instanceTypeCompareCmd struct {
+	Types []string `name:"types" arg:"required" help:"Instance types to compare, for example, t3.large m6i.large"`
+}
+
+type instanceTypeCmd struct {
+	List    instanceTypeListCmd    `name:"ls" cmd:"" help:"List instance types"`
+	Compare instanceTypeCompareCmd `name:"compare" cmd:"" help:"Compare specs of instance types side by side"`
+}
+
+type spotRequestListCmd struct {
+	States []string `name:"state" help:"Return spot requests of specific state(s) | values (open | active | closed | cancelled | failed)" default:""`
+}
+
+type spotPriceHistoryCmd struct {
+	InstanceTypes     []string `name:"type" required:"" help:"Return spot prices of specific instance type(s) (for example, m5.large), history of all types is too large to page"`
+	AvailabilityZones []string `name:"az" help:"Return spot prices of specific availability zone(s)" default:""`
+	Products          []string `name:"product" help:"Return spot prices of specific product(s) | values (Linux/UNIX | Windows | Red Hat Enterprise Linux | SUSE Linux) | Default is Linux/UNIX" default:""`
+	timeRangeFlags
+}
+
+type spotCmd struct {
+	Requests spotRequestListCmd  `name:"requests" cmd:"" help:"List spot instance requests"`
+	Prices   spotPriceHistoryCmd `name:"prices" cmd:"" help:"Show spot price history with trend per availability zone, of last 7 days unless --since is set"`
+}
+
+type launchTemplateListCmd struct {
+	Names []string `name:"name" help:"Return launch templates of specific name(s), You can use a wildcard (*), for example, web-*" default:""`
+}
+
+type launchTemplateDefinitionCmd struct {
+	Id      string `name:"id" arg:"required" help:"Launch template id or name"`
+	Version string `name:"version" help:"Version number, $Latest or $Default | Default is $Default"`
+}
+
+type launchTemplateDiffCmd struct {
+	Id   string `name:"id" arg:"required" help:"Launch template id or name"`
+	From string `name:"from" arg:"required" help:"Version number, $Latest or $Default"`
+	To   string `name:"to" arg:"required" help:"Version number, $Latest or $Default"`
+}
+
+type launchTemplateCmd struct {
+	List       launchTemplateListCmd       `name:"ls" cmd:"" help:"List launch templates"`
+	Definition launchTemplateDefinitionCmd `name:"def" cmd:"" help:"Get launch template version with security groups, block devices and decoded user data"`
+	Diff       launchTemplateDiffCmd       `name:"diff" cmd:"" help:"Show field level difference between two versions of launch template"`
+}
+
 type EC2Command struct {
 	List               eC2ListCmd            `name:"ls" cmd:"" help:"List ec2 instances"`
 	InstacneDefinition instanceDefinitionCmd `name:"def" cmd:"" help:"Get ec2 instance definition"`
+	SecurityGroup      securityGroupCmd      `name:"sg" cmd:"" help:"Operation on security groups"`
+	Reachability       reachabilityCmd       `name:"reach" cmd:"" help:"Check whether source can reach instance on port, evaluates security groups and network ACLs"`
+	Volume             volumeCmd             `name:"volumes" cmd:"" help:"Operation on EBS volumes"`
+	Snapshot           snapshotCmd           `name:"snapshots" cmd:"" help:"Operation on EBS snapshots"`
+	Image              imageCmd              `name:"images" cmd:"" help:"Operation on AMIs"`
+	ElasticIp          elasticIpCmd          `name:"eips" cmd:"" help:"Operation on elastic ips"`
+	NetworkInterface   networkInterfaceCmd   `name:"enis" cmd:"" help:"Operation on network interfaces"`
+	Connect            connectCmd            `name:"connect" cmd:"" help:"Open shell or forward port on instance via ssm session manager or ssh"`
+	ConsoleOutput      consoleOutputCmd      `name:"console" cmd:"" help:"Get instance console output"`
+	UserData           userDataCmd           `name:"userdata" cmd:"" help:"Get decoded instance user data"`
+	InstanceType       instanceTypeCmd       `name:"types" cmd:"" help:"Operation on instance types"`
+	LaunchTemplate     launchTemplateCmd     `name:"launch-templates" cmd:"" help:"Operation on launch templates"`
+	Spot               spotCmd               `name:"spot" cmd:"" help:"Operation on spot instance requests and prices"`
 }
 
 func (cmd *eC2ListCmd) Run(globals *globals.CLIFlag) error {
@@ -32,6 +247,8 @@ func (cmd *eC2ListCmd) Run(globals *globals.CLIFlag) error {
 		ec2.WithInstanceStates(cmd.InstanceStates),
 		ec2.WithInstanceType(cmd.InstanceTypes),
 		ec2.WithSubnetsIds(cmd.SubnetIds),
+		ec2.WithAsgNames(cmd.AsgNames),
+		ec2.WithLifecycles(cmd.Lifecycles),
 		ec2.WithVpcIds(cmd.VpcIds),
 	}
 	if cmd.HasPublicIp != nil {
@@ -40,10 +257,35 @@ func (cmd *eC2ListCmd) Run(globals *globals.CLIFlag) error {
 	if cmd.LaunchAtString != nil {
 		filters = append(filters, ec2.WithLaunchAt(*cmd.LaunchAtString))
 	}
+	if cmd.Impaired {
+		filters = append(filters, ec2.WithImpaired())
+	}
+	where, err := compileWhere(cmd.Where, ec2.InstanceSchema, globals)
+	if err != nil {
+		return err
+	}
+	launchRange, err := cmd.timeRange(globals)
+	if err != nil {
+		return err
+	}
+	filters = append(filters, ec2.WithWhere(where), ec2.WithLaunchRange(launchRange))
 	filter := ec2.NewInstanceFilter(filters...)
 
-	icmd := ec2.NewinstanceListCommandExecutor(globals, *filter)
-	err := icmd.Execute()
+	groupBy, err := ec2.ParseInstanceGroupBy(cmd.GroupBy)
+	if err != nil {
+		return err
+	}
+	viewOpts := []ec2.InstanceListViewOptFunc{
+		ec2.WithGroupBy(groupBy),
+		ec2.WithSortBy(cmd.SortBy, cmd.Order),
+	}
+	if cmd.Summary {
+		viewOpts = append(viewOpts, ec2.WithSummary())
+	}
+	view := ec2.NewInstanceListView(viewOpts...)
+
+	icmd := ec2.NewinstanceListCommandExecutor(globals, *filter, cmd.ShowNames, cmd.ShowStatus, view)
+	err = icmd.Execute()
 	if err != nil {
 		return err
 	}
@@ -60,3 +302,321 @@ func (cmd *instanceDefinitionCmd) Run(globals *globals.CLIFlag) error {
 	}
 	return nil
 }
+
+func (cmd *securityGroupListCmd) Run(globals *globals.CLIFlag) error {
+	filter := ec2.NewSecurityGroupFilter(
+		ec2.WithSecurityGroupVpcIds(cmd.VpcIds),
+		ec2.WithSecurityGroupNames(cmd.GroupNames),
+		ec2.WithSecurityGroupTags(cmd.Tags),
+	)
+	icmd := ec2.NewSecurityGroupListCommandExecutor(globals, filter)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *securityGroupDefinitionCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewSecurityGroupDescribeCommandExecutor(globals, cmd.Id)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *securityGroupAuditCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewSecurityGroupAuditCommandExecutor(globals, cmd.Regions, cmd.AllRegions, cmd.Egress)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *reachabilityCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewReachabilityCommandExecutor(globals, cmd.From, cmd.To, cmd.Protocol, cmd.Port)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *volumeListCmd) Run(globals *globals.CLIFlag) error {
+	filters := []ec2.VolumeListFilterOptFunc{
+		ec2.WithVolumeTypes(cmd.VolumeTypes),
+		ec2.WithVolumeAvailabilityZones(cmd.AvailabilityZones),
+		ec2.WithVolumeSizeRange(cmd.MinSize, cmd.MaxSize),
+	}
+	if cmd.Unattached {
+		filters = append(filters, ec2.WithVolumeUnattached())
+	}
+	if cmd.Encrypted != nil {
+		filters = append(filters, ec2.WithVolumeEncrypted(*cmd.Encrypted))
+	}
+	where, err := compileWhere(cmd.Where, ec2.VolumeSchema, globals)
+	if err != nil {
+		return err
+	}
+	filters = append(filters, ec2.WithVolumeWhere(where))
+	icmd := ec2.NewVolumeListCommandExecutor(globals, ec2.NewVolumeFilter(filters...))
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *volumeCleanupCmd) Run(globals *globals.CLIFlag) error {
+	if cmd.Delete && cmd.OlderThan == nil {
+		return fmt.Errorf("--delete requires --older-than, so recently detached volumes and new snapshots are kept")
+	}
+	cutoff, err := olderThan(cmd.OlderThan, globals)
+	if err != nil {
+		return err
+	}
+	olderThanValue := ""
+	if cmd.OlderThan != nil {
+		olderThanValue = *cmd.OlderThan
+	}
+	icmd := ec2.NewVolumeCleanupCommandExecutor(globals, cutoff, olderThanValue, cmd.Delete, cmd.AutoApprove)
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *snapshotListCmd) Run(globals *globals.CLIFlag) error {
+	startRange, err := cmd.timeRange(globals)
+	if err != nil {
+		return err
+	}
+	where, err := compileWhere(cmd.Where, ec2.SnapshotSchema, globals)
+	if err != nil {
+		return err
+	}
+	filter := ec2.NewSnapshotFilter(
+		ec2.WithSnapshotOwners(cmd.Owners),
+		ec2.WithSnapshotVolumeIds(cmd.VolumeIds),
+		ec2.WithSnapshotStartRange(startRange),
+		ec2.WithSnapshotWhere(where),
+	)
+	icmd := ec2.NewSnapshotListCommandExecutor(globals, filter)
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *snapshotCreateCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewSnapshotCreateCommandExecutor(globals, cmd.Instance, cmd.Description, cmd.Tags, cmd.ExcludeBootVolume)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *imageListCmd) Run(globals *globals.CLIFlag) error {
+	where, err := compileWhere(cmd.Where, ec2.ImageSchema, globals)
+	if err != nil {
+		return err
+	}
+	filter := ec2.NewImageFilter(
+		ec2.WithImageOwners(cmd.Owners),
+		ec2.WithImageNames(cmd.Names),
+		ec2.WithImageArchitectures(cmd.Architectures),
+		ec2.WithImageWhere(where),
+	)
+	icmd := ec2.NewImageListCommandExecutor(globals, filter)
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *imageDefinitionCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewImageDescribeCommandExecutor(globals, cmd.Id)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *imageUnusedCmd) Run(globals *globals.CLIFlag) error {
+	if cmd.Deregister && cmd.OlderThan == nil {
+		return fmt.Errorf("--deregister requires --older-than, so recently created images are kept")
+	}
+	cutoff, err := olderThan(cmd.OlderThan, globals)
+	if err != nil {
+		return err
+	}
+	olderThanValue := ""
+	if cmd.OlderThan != nil {
+		olderThanValue = *cmd.OlderThan
+	}
+	filter := ec2.NewImageFilter(ec2.WithImageOlderThan(cutoff))
+	icmd := ec2.NewImageUnusedCommandExecutor(globals, filter, olderThanValue, cmd.Deregister, cmd.AutoApprove)
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *elasticIpListCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewElasticIpListCommandExecutor(globals, cmd.Unassociated)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *networkInterfaceListCmd) Run(globals *globals.CLIFlag) error {
+	filters := []ec2.NetworkInterfaceListFilterOptFunc{
+		ec2.WithNetworkInterfaceVpcIds(cmd.VpcIds),
+		ec2.WithNetworkInterfaceSubnetIds(cmd.SubnetIds),
+		ec2.WithNetworkInterfaceTypes(cmd.InterfaceTypes),
+	}
+	if cmd.Available {
+		filters = append(filters, ec2.WithNetworkInterfaceAvailable())
+	}
+	icmd := ec2.NewNetworkInterfaceListCommandExecutor(globals, ec2.NewNetworkInterfaceFilter(filters...))
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *connectCmd) Run(globals *globals.CLIFlag) error {
+	var forward *ec2.PortForward
+	if cmd.Forward != nil {
+		var err error
+		if forward, err = ec2.ParsePortForward(*cmd.Forward); err != nil {
+			return err
+		}
+	}
+	icmd := ec2.NewConnectCommandExecutor(globals, cmd.Target, cmd.Method, cmd.IP, cmd.User, cmd.KeyFile, forward)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *consoleOutputCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewConsoleOutputCommandExecutor(globals, cmd.Target, cmd.Latest, cmd.Tail, cmd.ScreenshotFile)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *userDataCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewUserDataCommandExecutor(globals, cmd.Target)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *instanceTypeListCmd) Run(globals *globals.CLIFlag) error {
+	filters := []ec2.InstanceTypeListFilterOptFunc{
+		ec2.WithInstanceTypeNames(cmd.Types),
+		ec2.WithInstanceTypeArchitectures(cmd.Architectures),
+		ec2.WithInstanceTypeVcpuRange(cmd.MinVcpus, cmd.MaxVcpus),
+		ec2.WithInstanceTypeMemoryRange(cmd.MinMemory, cmd.MaxMemory),
+		ec2.WithInstanceTypeNetwork(cmd.Network),
+	}
+	if cmd.NoGpu {
+		filters = append(filters, ec2.WithInstanceTypeNoGpu())
+	}
+	if cmd.EbsOptimized {
+		filters = append(filters, ec2.WithInstanceTypeEbsOptimized())
+	}
+	if cmd.CurrentGeneration {
+		filters = append(filters, ec2.WithInstanceTypeCurrentGeneration())
+	}
+	icmd := ec2.NewInstanceTypeListCommandExecutor(globals, ec2.NewInstanceTypeFilter(filters...))
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *instanceTypeCompareCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewInstanceTypeCompareCommandExecutor(globals, cmd.Types)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *launchTemplateListCmd) Run(globals *globals.CLIFlag) error {
+	filter := ec2.NewLaunchTemplateFilter(ec2.WithLaunchTemplateNames(cmd.Names))
+	icmd := ec2.NewLaunchTemplateListCommandExecutor(globals, filter)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *launchTemplateDefinitionCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewLaunchTemplateDescribeCommandExecutor(globals, cmd.Id, cmd.Version)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *launchTemplateDiffCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewLaunchTemplateDiffCommandExecutor(globals, cmd.Id, cmd.From, cmd.To)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *spotRequestListCmd) Run(globals *globals.CLIFlag) error {
+	filter := ec2.NewSpotRequestFilter(ec2.WithSpotRequestStates(cmd.States))
+	icmd := ec2.NewSpotRequestListCommandExecutor(globals, filter)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *spotPriceHistoryCmd) Run(globals *globals.CLIFlag) error {
+	priceRange, err := cmd.timeRange(globals)
+	if err != nil {
+		return err
+	}
+	filter := ec2.NewSpotPriceHistoryFilter(
+		ec2.WithSpotPriceInstanceTypes(cmd.InstanceTypes),
+		ec2.WithSpotPriceAvailabilityZones(cmd.AvailabilityZones),
+		ec2.WithSpotPriceProducts(cmd.Products),
+		ec2.WithSpotPriceRange(priceRange),
+	)
+	icmd := ec2.NewSpotPriceHistoryCommandExecutor(globals, filter)
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
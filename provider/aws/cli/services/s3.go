@@ -3,17 +3,35 @@ package services
 import (
 	"cloudctl/provider/aws/cli/globals"
 	"cloudctl/provider/aws/services/s3"
+	ctltime "cloudctl/time"
+	"fmt"
+	"regexp"
+
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
 )
 
 type listCmd struct {
 	BucketNameInString   *string `name:"name" help:"List of bucket which contains provided value in their name"`
-	CreationDateInString *string `name:"createAt" help:"The time when the bucket was created, in the ISO 8601 format in the UTC time zone (YYYY-MM-DDThh:mm:ss.sssZ), for example, 2021-09-29T11:04:43.305Z. You can use a wildcard (*), for example, 2021-09-29T*"`
+	CreationDateInString *string `name:"createAt" help:"Deprecated, use --since/--until | The time when the bucket was created, in the ISO 8601 format in the UTC time zone (YYYY-MM-DDThh:mm:ss.sssZ), for example, 2021-09-29T11:04:43.305Z. You can use a wildcard (*), for example, 2021-09-29T*"`
+	Where                *string `name:"where" help:"Filter with expression over bucket fields, for example, name =~ '^logs-' && creationDate < '2023-01-01' | Unknown field error lists supported fields"`
+	timeRangeFlags
 }
 
 type listBucketObjectsCmd struct {
-	ObjectPrefix  *string `name:"prefix" help:"Bucket Object prefix"`
-	MaxKeysReturn int64   `name:"max-keys" default:"1000" help:"Number of bucket objects return | Default value is 1000"`
-	BucketName    string  `name:"name" arg:"required" help:"Bucket name"`
+	ObjectPrefix   *string  `name:"prefix" help:"Bucket Object prefix"`
+	MaxKeysReturn  int64    `name:"max-keys" default:"1000" help:"Number of bucket objects return, listing continues until as many objects match filters | Default value is 1000"`
+	BucketName     string   `name:"name" arg:"required" help:"Bucket name"`
+	MinSize        *string  `name:"min-size" help:"Return objects of at least this size, in bytes or with unit (for example, 10KB, 5MB, 1GB)"`
+	MaxSize        *string  `name:"max-size" help:"Return objects of at most this size, in bytes or with unit (for example, 10KB, 5MB, 1GB)"`
+	ModifiedAfter  string   `name:"modified-after" xor:"since" help:"Return objects modified at or after time, same as --since"`
+	ModifiedBefore string   `name:"modified-before" xor:"until" help:"Return objects modified before time, same as --until"`
+	StorageClasses []string `name:"storage-class" help:"Return objects of specific storage class(es) (for example, STANDARD, GLACIER)"`
+	KeyRegex       *string  `name:"key-regex" help:"Return objects whose key matches the regular expression"`
+	SortBy         string   `name:"sort" enum:"key,size,modified" default:"modified" help:"Sort objects by, supported input [key,size,modified]"`
+	Ascending      bool     `name:"asc" xor:"order" help:"Sort in ascending order, default for key and size"`
+	Descending     bool     `name:"desc" xor:"order" help:"Sort in descending order, default for modified"`
+	Where          *string  `name:"where" help:"Filter with expression over object fields, for example, size > 1048576 && storageClass != 'GLACIER' | Unknown field error lists supported fields"`
+	timeRangeFlags
 }
 
 type bucketDefinitionCmd struct {
@@ -21,10 +39,117 @@ type bucketDefinitionCmd struct {
 }
 
 type bucketObjectDownloadCmd struct {
-	BucketName string `name:"name" arg:"required" help:"Bucket name"`
-	Key        string `name:"key" arg:"required" help:"Bucket key or key prefix"`
-	Path       string `name:"path" type:"path" help:"Path to local store the object(s), Default is current directory" arg:"required" default:"."`
-	Recursive  bool   `name:"recursive" help:"This mode will download all objects recursively with provided key as prefix"`
+	BucketName  string `name:"name" arg:"required" help:"Bucket name"`
+	Key         string `name:"key" arg:"required" help:"Bucket key or key prefix"`
+	Path        string `name:"path" type:"path" help:"Path to local store the object(s), Default is current directory" arg:"required" default:"."`
+	Recursive   bool   `name:"recursive" help:"This mode will download all objects recursively with provided key as prefix"`
+	WaitRestore bool   `name:"wait-restore" help:"Wait until restore of archived (GLACIER/DEEP_ARCHIVE) object(s) completes before download"`
+	MaxWait     string `name:"max-wait" help:"Stop waiting for restore after duration, for example 6h, 2d | Default is 12h" default:"12h"`
+}
+
+type bucketObjectRestoreCmd struct {
+	BucketName    string  `name:"name" arg:"required" help:"Bucket name"`
+	Key           *string `name:"key" xor:"target" help:"Restore a single object"`
+	ObjectPrefix  *string `name:"prefix" xor:"target" help:"Restore all archived objects with the prefix"`
+	MaxKeysReturn int64   `name:"max-keys" default:"1000" help:"Number of bucket objects to restore with prefix | Default value is 1000"`
+	Days          int64   `name:"days" default:"1" help:"Number of days restored copy remains available"`
+	Tier          string  `name:"tier" enum:"Standard,Bulk,Expedited" default:"Standard" help:"Retrieval tier, supported input [Standard,Bulk,Expedited]"`
+}
+
+type bucketConfigFileCmd struct {
+	BucketName  string `name:"name" arg:"required" help:"Bucket name"`
+	File        string `name:"file" short:"f" type:"existingfile" required:"" help:"Configuration file in JSON or YAML(.yaml/.yml) format"`
+	AutoApprove bool   `name:"yes" short:"y" help:"Apply change without confirmation"`
+}
+
+type bucketConfigCmd struct {
+	BucketName  string `name:"name" arg:"required" help:"Bucket name"`
+	AutoApprove bool   `name:"yes" short:"y" help:"Apply change without confirmation"`
+}
+
+type bucketPolicySetCmd struct {
+	bucketConfigFileCmd
+}
+
+type bucketPolicyDeleteCmd struct {
+	bucketConfigCmd
+}
+
+type bucketPolicyCmd struct {
+	Set    bucketPolicySetCmd    `name:"set" cmd:"" help:"Set bucket policy from file"`
+	Delete bucketPolicyDeleteCmd `name:"delete" cmd:"" help:"Delete bucket policy"`
+}
+
+type bucketLifecycleSetCmd struct {
+	bucketConfigFileCmd
+}
+
+type bucketLifecycleCmd struct {
+	Set bucketLifecycleSetCmd `name:"set" cmd:"" help:"Set bucket lifecycle configuration from file"`
+}
+
+type bucketCorsSetCmd struct {
+	bucketConfigFileCmd
+}
+
+type bucketCorsCmd struct {
+	Set bucketCorsSetCmd `name:"set" cmd:"" help:"Set bucket CORS configuration from file"`
+}
+
+type bucketTagsSetCmd struct {
+	bucketConfigFileCmd
+}
+
+type bucketTagsAddCmd struct {
+	bucketConfigCmd
+	Tags map[string]string `name:"tag" arg:"required" help:"Tag(s) to add or update in key=value format"`
+}
+
+type bucketTagsRemoveCmd struct {
+	bucketConfigCmd
+	Keys []string `name:"key" arg:"required" help:"Tag key(s) to remove"`
+}
+
+type bucketTagsCmd struct {
+	Set    bucketTagsSetCmd    `name:"set" cmd:"" help:"Replace bucket tags with key/value map from file"`
+	Add    bucketTagsAddCmd    `name:"add" cmd:"" help:"Add or update bucket tags"`
+	Remove bucketTagsRemoveCmd `name:"remove" cmd:"" help:"Remove bucket tags"`
+}
+
+type bucketVersioningEnableCmd struct {
+	bucketConfigCmd
+}
+
+type bucketVersioningSuspendCmd struct {
+	bucketConfigCmd
+}
+
+type bucketVersioningCmd struct {
+	Enable  bucketVersioningEnableCmd  `name:"enable" cmd:"" help:"Enable bucket versioning"`
+	Suspend bucketVersioningSuspendCmd `name:"suspend" cmd:"" help:"Suspend bucket versioning"`
+}
+
+type bucketEncryptionSetCmd struct {
+	bucketConfigFileCmd
+}
+
+type bucketEncryptionCmd struct {
+	Set bucketEncryptionSetCmd `name:"set" cmd:"" help:"Set bucket default encryption from file"`
+}
+
+type bucketCreateCmd struct {
+	BucketName        string            `name:"name" arg:"required" help:"Bucket name, bucket is created in the configured region"`
+	Versioning        bool              `name:"versioning" help:"Enable bucket versioning"`
+	Encryption        *string           `name:"encryption" enum:"AES256,aws:kms" help:"Default encryption algorithm, supported input [AES256,aws:kms]"`
+	KmsKeyId          *string           `name:"kms-key-id" help:"KMS key id used with aws:kms encryption"`
+	BlockPublicAccess bool              `name:"block-public-access" default:"true" negatable:"" help:"Block all public access | Default value is true"`
+	Tags              map[string]string `name:"tag" help:"Bucket tag(s) in key=value format"`
+}
+
+type bucketDeleteCmd struct {
+	BucketName  string `name:"name" arg:"required" help:"Bucket name"`
+	Force       bool   `name:"force" help:"Delete all objects including versions before delete bucket, requires bucket name as confirmation"`
+	AutoApprove bool   `name:"yes" short:"y" help:"Delete bucket with all content without confirmation"`
 }
 
 type S3Command struct {
@@ -32,17 +157,36 @@ type S3Command struct {
 	ListBucketObjects    listBucketObjectsCmd    `name:"list-objects" cmd:"" help:"Return list of objects of s3 bucket"`
 	BucketDefinition     bucketDefinitionCmd     `name:"def" cmd:"" help:"Return bucket definition"`
 	BucketObjectDownload bucketObjectDownloadCmd `name:"get" cmd:"" help:"Download bucket object(s)"`
+	BucketObjectRestore  bucketObjectRestoreCmd  `name:"restore" cmd:"" help:"Restore archived (GLACIER/DEEP_ARCHIVE) object(s)"`
+	Policy               bucketPolicyCmd         `name:"policy" cmd:"" help:"Manage bucket policy"`
+	Lifecycle            bucketLifecycleCmd      `name:"lifecycle" cmd:"" help:"Manage bucket lifecycle configuration"`
+	Cors                 bucketCorsCmd           `name:"cors" cmd:"" help:"Manage bucket CORS configuration"`
+	Tags                 bucketTagsCmd           `name:"tags" cmd:"" help:"Manage bucket tags"`
+	Versioning           bucketVersioningCmd     `name:"versioning" cmd:"" help:"Manage bucket versioning"`
+	Encryption           bucketEncryptionCmd     `name:"encryption" cmd:"" help:"Manage bucket default encryption"`
+	MakeBucket           bucketCreateCmd         `name:"mb" cmd:"" help:"Create bucket"`
+	RemoveBucket         bucketDeleteCmd         `name:"rb" cmd:"" help:"Delete bucket"`
 }
 
 func (cmd *listCmd) Run(flag *globals.CLIFlag) error {
 
+	where, err := compileWhere(cmd.Where, s3.BucketSchema, flag)
+	if err != nil {
+		return err
+	}
+	creationRange, err := cmd.timeRange(flag)
+	if err != nil {
+		return err
+	}
 	filter := s3.NewBucketListFilter(
 		s3.WithBucketNameFilter(cmd.BucketNameInString),
 		s3.WithCreationDateFilter(cmd.CreationDateInString),
+		s3.WithBucketCreationRange(creationRange),
+		s3.WithBucketWhere(where),
 	)
 
 	icmd := s3.NewBucketListCommandExecutor(flag, filter)
-	err := icmd.Execute()
+	err = icmd.Execute()
 	if err != nil {
 		return err
 	}
@@ -50,14 +194,64 @@ func (cmd *listCmd) Run(flag *globals.CLIFlag) error {
 }
 
 func (cmd *listBucketObjectsCmd) Run(flag *globals.CLIFlag) error {
-	icmd := s3.NewBucketObjectListCommandExecutor(flag, cmd.BucketName, cmd.ObjectPrefix, cmd.MaxKeysReturn)
-	err := icmd.Execute()
+	filterOptFuncs, err := cmd.filterOptFuncs(flag)
+	if err != nil {
+		return err
+	}
+	icmd := s3.NewBucketObjectListCommandExecutor(flag, cmd.BucketName, cmd.ObjectPrefix, cmd.MaxKeysReturn, s3.NewBucketObjectListFilter(filterOptFuncs...))
+	err = icmd.Execute()
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+func (cmd *listBucketObjectsCmd) filterOptFuncs(flag *globals.CLIFlag) ([]s3.BucketObjectListFilterOptFunc, error) {
+	var minSize, maxSize *int64
+	var keyRegex *regexp.Regexp
+	if cmd.MinSize != nil {
+		size, err := s3.ParseSize(*cmd.MinSize)
+		if err != nil {
+			return nil, err
+		}
+		minSize = &size
+	}
+	if cmd.MaxSize != nil {
+		size, err := s3.ParseSize(*cmd.MaxSize)
+		if err != nil {
+			return nil, err
+		}
+		maxSize = &size
+	}
+	modifiedRange, err := cmd.timeRangeWith(cmd.ModifiedAfter, cmd.ModifiedBefore, flag)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.KeyRegex != nil {
+		regex, err := regexp.Compile(*cmd.KeyRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --key-regex: %w", err)
+		}
+		keyRegex = regex
+	}
+	where, err := compileWhere(cmd.Where, s3.ObjectSchema, flag)
+	if err != nil {
+		return nil, err
+	}
+	ascending := cmd.SortBy != s3.SORT_BY_MODIFIED
+	if cmd.Ascending || cmd.Descending {
+		ascending = cmd.Ascending
+	}
+	return []s3.BucketObjectListFilterOptFunc{
+		s3.WithObjectSizeRange(minSize, maxSize),
+		s3.WithObjectModifiedRange(modifiedRange),
+		s3.WithObjectStorageClasses(cmd.StorageClasses),
+		s3.WithObjectKeyRegex(keyRegex),
+		s3.WithObjectSort(cmd.SortBy, ascending),
+		s3.WithObjectWhere(where),
+	}, nil
+}
+
 func (cmd *bucketDefinitionCmd) Run(flag *globals.CLIFlag) error {
 	icmd := s3.NewBucketViewCommandExecutor(flag, cmd.BucketName)
 	err := icmd.Execute()
@@ -68,7 +262,113 @@ func (cmd *bucketDefinitionCmd) Run(flag *globals.CLIFlag) error {
 }
 
 func (cmd *bucketObjectDownloadCmd) Run(flag *globals.CLIFlag) error {
-	icmd := s3.NewBucketObjectDownloadCommandExecutor(flag, cmd.BucketName, cmd.Key, cmd.Path, cmd.Recursive)
+	maxWait, err := ctltime.ParseDuration(cmd.MaxWait)
+	if err != nil {
+		return err
+	}
+	icmd := s3.NewBucketObjectDownloadCommandExecutor(flag, cmd.BucketName, cmd.Key, cmd.Path, cmd.Recursive, cmd.WaitRestore, maxWait)
+	err = icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *bucketObjectRestoreCmd) Run(flag *globals.CLIFlag) error {
+	if cmd.Key == nil && cmd.ObjectPrefix == nil {
+		return s3.NoRestoreTargetProvided()
+	}
+	icmd := s3.NewBucketObjectRestoreCommandExecutor(flag, cmd.BucketName, cmd.Key, cmd.ObjectPrefix, cmd.MaxKeysReturn, cmd.Days, cmd.Tier)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *bucketPolicySetCmd) Run(flag *globals.CLIFlag) error {
+	change, err := s3.NewPolicySetChange(cmd.File)
+	if err != nil {
+		return err
+	}
+	return executeBucketConfigChange(flag, cmd.BucketName, change, cmd.AutoApprove)
+}
+
+func (cmd *bucketPolicyDeleteCmd) Run(flag *globals.CLIFlag) error {
+	return executeBucketConfigChange(flag, cmd.BucketName, s3.NewPolicyDeleteChange(), cmd.AutoApprove)
+}
+
+func (cmd *bucketLifecycleSetCmd) Run(flag *globals.CLIFlag) error {
+	change, err := s3.NewLifecycleSetChange(cmd.File)
+	if err != nil {
+		return err
+	}
+	return executeBucketConfigChange(flag, cmd.BucketName, change, cmd.AutoApprove)
+}
+
+func (cmd *bucketCorsSetCmd) Run(flag *globals.CLIFlag) error {
+	change, err := s3.NewCorsSetChange(cmd.File)
+	if err != nil {
+		return err
+	}
+	return executeBucketConfigChange(flag, cmd.BucketName, change, cmd.AutoApprove)
+}
+
+func (cmd *bucketTagsSetCmd) Run(flag *globals.CLIFlag) error {
+	change, err := s3.NewTagsSetChange(cmd.File)
+	if err != nil {
+		return err
+	}
+	return executeBucketConfigChange(flag, cmd.BucketName, change, cmd.AutoApprove)
+}
+
+func (cmd *bucketTagsAddCmd) Run(flag *globals.CLIFlag) error {
+	return executeBucketConfigChange(flag, cmd.BucketName, s3.NewTagsAddChange(cmd.Tags), cmd.AutoApprove)
+}
+
+func (cmd *bucketTagsRemoveCmd) Run(flag *globals.CLIFlag) error {
+	return executeBucketConfigChange(flag, cmd.BucketName, s3.NewTagsRemoveChange(cmd.Keys), cmd.AutoApprove)
+}
+
+func (cmd *bucketVersioningEnableCmd) Run(flag *globals.CLIFlag) error {
+	return executeBucketConfigChange(flag, cmd.BucketName, s3.NewVersioningChange(awss3.BucketVersioningStatusEnabled), cmd.AutoApprove)
+}
+
+func (cmd *bucketVersioningSuspendCmd) Run(flag *globals.CLIFlag) error {
+	return executeBucketConfigChange(flag, cmd.BucketName, s3.NewVersioningChange(awss3.BucketVersioningStatusSuspended), cmd.AutoApprove)
+}
+
+func (cmd *bucketEncryptionSetCmd) Run(flag *globals.CLIFlag) error {
+	change, err := s3.NewEncryptionSetChange(cmd.File)
+	if err != nil {
+		return err
+	}
+	return executeBucketConfigChange(flag, cmd.BucketName, change, cmd.AutoApprove)
+}
+
+func (cmd *bucketCreateCmd) Run(flag *globals.CLIFlag) error {
+	if cmd.KmsKeyId != nil && (cmd.Encryption == nil || *cmd.Encryption != awss3.ServerSideEncryptionAwsKms) {
+		return fmt.Errorf("--kms-key-id requires --encryption=%s", awss3.ServerSideEncryptionAwsKms)
+	}
+	icmd := s3.NewBucketCreateCommandExecutor(flag, cmd.BucketName, cmd.Versioning, cmd.Encryption, cmd.KmsKeyId, cmd.BlockPublicAccess, cmd.Tags)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *bucketDeleteCmd) Run(flag *globals.CLIFlag) error {
+	icmd := s3.NewBucketDeleteCommandExecutor(flag, cmd.BucketName, cmd.Force, cmd.AutoApprove)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func executeBucketConfigChange(flag *globals.CLIFlag, bucketName string, change *s3.BucketConfigChange, autoApprove bool) error {
+	icmd := s3.NewBucketConfigChangeCommandExecutor(flag, bucketName, change, autoApprove)
 	err := icmd.Execute()
 	if err != nil {
 		return err
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"cloudctl/provider/aws/cli/globals"
+	ctltime "cloudctl/time"
+	"time"
+)
+
+// timeRangeFlags is embedded by list commands to filter on time of resource e.g. launch time of instance,
+// values are duration (7d, 2h), date (2024-01-31, RFC3339) or keyword (today, yesterday) interpreted in --tz
+type timeRangeFlags struct {
+	Since     string `name:"since" xor:"since" help:"Return resources launched, created or modified at or after time, for example 7d, 2024-01-31, yesterday"`
+	Until     string `name:"until" xor:"until" help:"Return resources launched, created or modified before time, for example 2h, 2024-02-01T10:00:00, today"`
+	OlderThan string `name:"older-than" xor:"until" help:"Return resources older than duration or time, same as --until, for example 30d, 2w"`
+	NewerThan string `name:"newer-than" xor:"since" help:"Return resources newer than duration or time, same as --since, for example 12h"`
+}
+
+// timeRange return nil if no flag is set
+func (f *timeRangeFlags) timeRange(flag *globals.CLIFlag) (*ctltime.Range, error) {
+	return f.timeRangeWith("", "", flag)
+}
+
+// timeRangeWith is timeRange where non empty since or until of command specific aliases e.g. --modified-after
+// take place of flags, flags are left unchanged
+func (f *timeRangeFlags) timeRangeWith(since, until string, flag *globals.CLIFlag) (*ctltime.Range, error) {
+	if len(since) == 0 {
+		since = f.Since
+		if len(f.NewerThan) != 0 {
+			since = f.NewerThan
+		}
+	}
+	if len(until) == 0 {
+		until = f.Until
+		if len(f.OlderThan) != 0 {
+			until = f.OlderThan
+		}
+	}
+	return ctltime.ParseRange(since, until, ctltime.GetTZ(flag.TZShortIdentifier).Now())
+}
+
+// olderThan parse --older-than of cleanup commands like --until, zero time is returned if flag isn't set
+func olderThan(value *string, flag *globals.CLIFlag) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, nil
+	}
+	return ctltime.ParseTime(*value, ctltime.GetTZ(flag.TZShortIdentifier).Now())
+}
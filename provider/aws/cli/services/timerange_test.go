@@ -0,0 +1,45 @@
+package services
+
+import (
+	"cloudctl/provider/aws/cli/globals"
+	"testing"
+	"time"
+)
+
+func TestModifiedRange(t *testing.T) {
+	flag := &globals.CLIFlag{TZShortIdentifier: "utc"}
+	since := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		cmd  listBucketObjectsCmd
+	}{
+		{"modified flags", listBucketObjectsCmd{ModifiedAfter: "2024-01-31", ModifiedBefore: "2024-02-01"}},
+		{"since & until", listBucketObjectsCmd{timeRangeFlags: timeRangeFlags{Since: "2024-01-31", Until: "2024-02-01"}}},
+		{"newer & older than", listBucketObjectsCmd{timeRangeFlags: timeRangeFlags{NewerThan: "2024-01-31", OlderThan: "2024-02-01"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := test.cmd
+			if _, err := cmd.filterOptFuncs(flag); err != nil {
+				t.Fatal(err)
+			}
+			if cmd.timeRangeFlags != test.cmd.timeRangeFlags {
+				t.Errorf("filterOptFuncs changed time range flags to %+v", cmd.timeRangeFlags)
+			}
+			modifiedRange, err := cmd.timeRangeWith(cmd.ModifiedAfter, cmd.ModifiedBefore, flag)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !modifiedRange.Contains(since) {
+				t.Errorf("object modified at %s must be returned, --modified-after is inclusive", since)
+			}
+			if modifiedRange.Contains(since.Add(-time.Second)) {
+				t.Errorf("object modified before %s must not be returned", since)
+			}
+			if modifiedRange.Contains(until) {
+				t.Errorf("object modified at %s must not be returned, --modified-before is exclusive", until)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"cloudctl/provider/aws/cli/globals"
+	"cloudctl/provider/aws/services/ec2"
+)
+
+type vpcListCmd struct {
+}
+
+type vpcDefinitionCmd struct {
+	Id string `name:"id" arg:"required" help:"Vpc id"`
+}
+
+type subnetListCmd struct {
+	VpcIds            []string `name:"vpc" help:"Return subnets of specific vpcId(s)" default:""`
+	AvailabilityZones []string `name:"az" help:"Return subnets of specific availability zone(s)" default:""`
+}
+
+type VPCCommand struct {
+	List       vpcListCmd       `name:"ls" cmd:"" help:"List vpcs"`
+	Definition vpcDefinitionCmd `name:"def" cmd:"" help:"Get vpc definition with subnets, route tables, gateways, endpoints and peering connections"`
+}
+
+type SubnetCommand struct {
+	List subnetListCmd `name:"ls" cmd:"" help:"List subnets"`
+}
+
+func (cmd *vpcListCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewVpcListCommandExecutor(globals)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *vpcDefinitionCmd) Run(globals *globals.CLIFlag) error {
+	icmd := ec2.NewVpcDescribeCommandExecutor(globals, cmd.Id)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cmd *subnetListCmd) Run(globals *globals.CLIFlag) error {
+	filter := ec2.NewSubnetFilter(
+		ec2.WithSubnetVpcIds(cmd.VpcIds),
+		ec2.WithSubnetAvailabilityZones(cmd.AvailabilityZones),
+	)
+	icmd := ec2.NewSubnetListCommandExecutor(globals, filter)
+	err := icmd.Execute()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"cloudctl/expr"
+	"cloudctl/provider/aws/cli/globals"
+	ctltime "cloudctl/time"
+)
+
+// compileWhere return nil expression if --where isn't set, relative times are resolved in --tz
+func compileWhere(where *string, schema expr.Schema, flag *globals.CLIFlag) (*expr.Expression, error) {
+	if where == nil {
+		return nil, nil
+	}
+	return expr.Compile(*where, schema, ctltime.GetTZ(flag.TZShortIdentifier).Now())
+}
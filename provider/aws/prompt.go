@@ -0,0 +1,46 @@
+package aws
+
+import "github.com/AlecAivazis/survey/v2"
+
+// Confirm ask user for yes/no, default is no
+func Confirm(message string) bool {
+	confirmed := false
+	prompt := &survey.Confirm{
+		Message: message,
+		Default: false,
+	}
+	if err := survey.AskOne(prompt, &confirmed); err != nil {
+		return false
+	}
+	return confirmed
+}
+
+// Confirmer return Confirm, or an always yes confirmation with autoApprove
+func Confirmer(autoApprove bool) func(message string) bool {
+	if autoApprove {
+		return func(string) bool { return true }
+	}
+	return Confirm
+}
+
+// InputConfirmer return ConfirmByInput of expected value, or an always yes confirmation with autoApprove
+func InputConfirmer(autoApprove bool, expected string) func(message string) bool {
+	if autoApprove {
+		return Confirmer(true)
+	}
+	return func(message string) bool {
+		return ConfirmByInput(message, expected)
+	}
+}
+
+// ConfirmByInput ask user to type the expected value, e.g. name of resource to be deleted
+func ConfirmByInput(message, expected string) bool {
+	answer := ""
+	prompt := &survey.Input{
+		Message: message,
+	}
+	if err := survey.AskOne(prompt, &answer); err != nil {
+		return false
+	}
+	return answer == expected
+}
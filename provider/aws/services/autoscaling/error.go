@@ -0,0 +1,13 @@
+package autoscaling
+
+import "fmt"
+
+func NoAutoScalingGroupFound() error {
+	return fmt.Errorf("no auto scaling group found")
+}
+func AutoScalingGroupNotFound(name string) error {
+	return fmt.Errorf("auto scaling group %s not found", name)
+}
+func NoLaunchConfigurationFound() error {
+	return fmt.Errorf("no launch configuration found")
+}
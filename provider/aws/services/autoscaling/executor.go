@@ -0,0 +1,46 @@
+package autoscaling
+
+import (
+	"cloudctl/executor"
+	"cloudctl/provider/aws"
+	"cloudctl/provider/aws/cli/globals"
+	"cloudctl/time"
+	"strings"
+)
+
+func NewAutoScalingGroupListCommandExecutor(flag *globals.CLIFlag, filter *AutoScalingGroupListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &autoScalingGroupListFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: autoScalingGroupListViewer,
+	}
+}
+
+func NewAutoScalingGroupDescribeCommandExecutor(flag *globals.CLIFlag, name string, activities int64) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &autoScalingGroupDefinitionFetcher{
+			client:     client,
+			tz:         time.GetTZ(flag.TZShortIdentifier),
+			name:       strings.TrimSpace(name),
+			activities: activities,
+		},
+		Viewer: autoScalingGroupInfoViewer,
+	}
+}
+
+func NewLaunchConfigurationListCommandExecutor(flag *globals.CLIFlag, filter *LaunchConfigurationListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &launchConfigurationListFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: launchConfigurationListViewer,
+	}
+}
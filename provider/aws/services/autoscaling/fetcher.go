@@ -0,0 +1,140 @@
+package autoscaling
+
+import (
+	"cloudctl/provider/aws"
+	"cloudctl/time"
+	"cloudctl/viewer"
+	"sort"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+type autoScalingGroupListFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	filter *AutoScalingGroupListFilter
+}
+
+type autoScalingGroupDefinitionFetcher struct {
+	client     *aws.Client
+	tz         *time.Timezone
+	name       string
+	activities int64
+}
+
+type launchConfigurationListFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	filter *LaunchConfigurationListFilter
+}
+
+func (f autoScalingGroupListFetcher) Fetch() interface{} {
+	groups, err := fetchAutoScalingGroups(f.client, nil)
+	if err != nil {
+		return &autoScalingGroupListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &autoScalingGroupListOutput{}
+	for _, group := range groups {
+		if f.filter.applyCustomFilter(group) {
+			output.groups = append(output.groups, newAutoScalingGroupSummary(group, f.tz))
+		}
+	}
+	if len(output.groups) == 0 {
+		return &autoScalingGroupListOutput{err: aws.NewErrorInfo(NoAutoScalingGroupFound(), viewer.INFO, nil)}
+	}
+	sort.Slice(output.groups, func(i, j int) bool {
+		return *output.groups[i].name < *output.groups[j].name
+	})
+	return output
+}
+
+func (f autoScalingGroupDefinitionFetcher) Fetch() interface{} {
+	groups, err := fetchAutoScalingGroups(f.client, []string{f.name})
+	if err != nil {
+		return &autoScalingGroupDefinition{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(groups) == 0 {
+		return &autoScalingGroupDefinition{err: aws.NewErrorInfo(AutoScalingGroupNotFound(f.name), viewer.WARN, nil)}
+	}
+	group := groups[0]
+	definition := &autoScalingGroupDefinition{
+		summary:           newAutoScalingGroupSummary(group, f.tz),
+		vpcZoneIdentifier: awssdk.StringValue(group.VPCZoneIdentifier),
+		targetGroups:      awssdk.StringValueSlice(group.TargetGroupARNs),
+	}
+	for _, instance := range group.Instances {
+		definition.instances = append(definition.instances, newAutoScalingGroupInstance(instance))
+	}
+	sort.Slice(definition.instances, func(i, j int) bool {
+		return *definition.instances[i].id < *definition.instances[j].id
+	})
+	for _, process := range group.SuspendedProcesses {
+		definition.suspendedProcesses = append(definition.suspendedProcesses, &suspendedProcess{
+			name:   awssdk.StringValue(process.ProcessName),
+			reason: awssdk.StringValue(process.SuspensionReason),
+		})
+	}
+
+	// activities are returned newest first
+	apiOutput, err := f.client.AutoScaling.DescribeScalingActivities(&autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: group.AutoScalingGroupName,
+		MaxRecords:           awssdk.Int64(f.activities),
+	})
+	if err != nil {
+		definition.activitiesErr = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+		return definition
+	}
+	for _, activity := range apiOutput.Activities {
+		definition.activities = append(definition.activities, newScalingActivity(activity, f.tz))
+	}
+	return definition
+}
+
+func fetchAutoScalingGroups(client *aws.Client, names []string) ([]*autoscaling.Group, error) {
+	groups := []*autoscaling.Group{}
+	input := &autoscaling.DescribeAutoScalingGroupsInput{}
+	if len(names) != 0 {
+		input.AutoScalingGroupNames = awssdk.StringSlice(names)
+	}
+	err := client.AutoScaling.DescribeAutoScalingGroupsPages(input, func(page *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) bool {
+		groups = append(groups, page.AutoScalingGroups...)
+		return true
+	})
+	return groups, err
+}
+
+// Fetch launch configurations with auto scaling groups using them
+func (f launchConfigurationListFetcher) Fetch() interface{} {
+	configs := []*autoscaling.LaunchConfiguration{}
+	err := f.client.AutoScaling.DescribeLaunchConfigurationsPages(&autoscaling.DescribeLaunchConfigurationsInput{}, func(page *autoscaling.DescribeLaunchConfigurationsOutput, lastPage bool) bool {
+		configs = append(configs, page.LaunchConfigurations...)
+		return true
+	})
+	if err != nil {
+		return &launchConfigurationListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	groups, err := fetchAutoScalingGroups(f.client, nil)
+	if err != nil {
+		return &launchConfigurationListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	usedBy := map[string][]string{}
+	for _, group := range groups {
+		if group.LaunchConfigurationName != nil {
+			usedBy[*group.LaunchConfigurationName] = append(usedBy[*group.LaunchConfigurationName], *group.AutoScalingGroupName)
+		}
+	}
+	output := &launchConfigurationListOutput{}
+	for _, config := range configs {
+		if f.filter.applyCustomFilter(config, usedBy[*config.LaunchConfigurationName]) {
+			output.configs = append(output.configs, newLaunchConfigurationSummary(config, usedBy[*config.LaunchConfigurationName], f.tz))
+		}
+	}
+	if len(output.configs) == 0 {
+		return &launchConfigurationListOutput{err: aws.NewErrorInfo(NoLaunchConfigurationFound(), viewer.INFO, nil)}
+	}
+	sort.Slice(output.configs, func(i, j int) bool {
+		return *output.configs[i].name < *output.configs[j].name
+	})
+	return output
+}
@@ -0,0 +1,129 @@
+package autoscaling
+
+import (
+	"cloudctl/expr"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+var (
+	// fields of --where expression
+	AutoScalingGroupSchema = expr.Schema{
+		"name":            expr.STRING,
+		"desired":         expr.NUMBER,
+		"min":             expr.NUMBER,
+		"max":             expr.NUMBER,
+		"instances":       expr.NUMBER,
+		"healthCheckType": expr.STRING,
+		"launchTemplate":  expr.STRING,
+		"status":          expr.STRING,
+		"createdTime":     expr.TIME,
+		"tags":            expr.MAP,
+	}
+)
+
+type AutoScalingGroupListFilterOptFunc func(*AutoScalingGroupListFilter)
+
+type AutoScalingGroupListFilter struct {
+	names []string
+	where *expr.Expression
+}
+
+// applyCustomFilter group name matches if it contains any of names, api supports only exact names
+func (f *AutoScalingGroupListFilter) applyCustomFilter(group *autoscaling.Group) bool {
+	if f.where != nil && !f.where.Match(autoScalingGroupRecord(group)) {
+		return false
+	}
+	if len(f.names) == 0 {
+		return true
+	}
+	for _, name := range f.names {
+		if strings.Contains(*group.AutoScalingGroupName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewAutoScalingGroupFilter(optfuncs ...AutoScalingGroupListFilterOptFunc) *AutoScalingGroupListFilter {
+	filter := &AutoScalingGroupListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithAutoScalingGroupNames(names []string) AutoScalingGroupListFilterOptFunc {
+	return func(filter *AutoScalingGroupListFilter) {
+		filter.names = names
+	}
+}
+
+func WithAutoScalingGroupWhere(where *expr.Expression) AutoScalingGroupListFilterOptFunc {
+	return func(filter *AutoScalingGroupListFilter) {
+		filter.where = where
+	}
+}
+
+func autoScalingGroupRecord(group *autoscaling.Group) expr.Record {
+	tags := map[string]string{}
+	for _, tag := range group.Tags {
+		tags[*tag.Key] = *tag.Value
+	}
+	return expr.Record{
+		"name":            group.AutoScalingGroupName,
+		"desired":         group.DesiredCapacity,
+		"min":             group.MinSize,
+		"max":             group.MaxSize,
+		"instances":       len(group.Instances),
+		"healthCheckType": group.HealthCheckType,
+		"launchTemplate":  launchTemplateOf(group),
+		"status":          group.Status,
+		"createdTime":     group.CreatedTime,
+		"tags":            tags,
+	}
+}
+
+type LaunchConfigurationListFilterOptFunc func(*LaunchConfigurationListFilter)
+
+type LaunchConfigurationListFilter struct {
+	names  []string
+	unused bool
+}
+
+// applyCustomFilter name matches if it contains any of names, usedBy are groups using the configuration
+func (f *LaunchConfigurationListFilter) applyCustomFilter(config *autoscaling.LaunchConfiguration, usedBy []string) bool {
+	if f.unused && len(usedBy) != 0 {
+		return false
+	}
+	if len(f.names) == 0 {
+		return true
+	}
+	for _, name := range f.names {
+		if strings.Contains(*config.LaunchConfigurationName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func NewLaunchConfigurationFilter(optfuncs ...LaunchConfigurationListFilterOptFunc) *LaunchConfigurationListFilter {
+	filter := &LaunchConfigurationListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithLaunchConfigurationNames(names []string) LaunchConfigurationListFilterOptFunc {
+	return func(filter *LaunchConfigurationListFilter) {
+		filter.names = names
+	}
+}
+
+func WithUnusedLaunchConfigurations(unused bool) LaunchConfigurationListFilterOptFunc {
+	return func(filter *LaunchConfigurationListFilter) {
+		filter.unused = unused
+	}
+}
@@ -0,0 +1,181 @@
+package autoscaling
+
+import (
+	"cloudctl/provider/aws"
+	ctltime "cloudctl/time"
+	"fmt"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+const (
+	NO_VALUE string = "-"
+)
+
+type autoScalingGroupSummary struct {
+	name               *string
+	desired            int64
+	min                int64
+	max                int64
+	instances          int
+	inService          int
+	launchTemplate     string
+	healthCheckType    string
+	availabilityZones  string
+	suspendedProcesses int
+	status             string
+	createdTime        *time.Time
+}
+
+type autoScalingGroupListOutput struct {
+	groups []*autoScalingGroupSummary
+	err    *aws.ErrorInfo
+}
+
+type autoScalingGroupInstance struct {
+	id             *string
+	typee          string
+	az             string
+	healthStatus   string
+	lifecycleState string
+	launchTemplate string
+	protected      bool
+}
+
+type suspendedProcess struct {
+	name   string
+	reason string
+}
+
+type scalingActivity struct {
+	startTime   *time.Time
+	status      string
+	description string
+	cause       string
+}
+
+type autoScalingGroupDefinition struct {
+	summary            *autoScalingGroupSummary
+	vpcZoneIdentifier  string
+	targetGroups       []string
+	instances          []*autoScalingGroupInstance
+	suspendedProcesses []*suspendedProcess
+	activities         []*scalingActivity
+	activitiesErr      *aws.ErrorInfo
+	err                *aws.ErrorInfo
+}
+
+type launchConfigurationSummary struct {
+	name           *string
+	imageId        string
+	instanceType   string
+	keyName        string
+	securityGroups []string
+	spotPrice      string
+	usedBy         []string
+	createdTime    *time.Time
+}
+
+type launchConfigurationListOutput struct {
+	configs []*launchConfigurationSummary
+	err     *aws.ErrorInfo
+}
+
+func newAutoScalingGroupSummary(group *autoscaling.Group, tz *ctltime.Timezone) *autoScalingGroupSummary {
+	summary := &autoScalingGroupSummary{
+		name:               group.AutoScalingGroupName,
+		desired:            awssdk.Int64Value(group.DesiredCapacity),
+		min:                awssdk.Int64Value(group.MinSize),
+		max:                awssdk.Int64Value(group.MaxSize),
+		instances:          len(group.Instances),
+		launchTemplate:     launchTemplateOf(group),
+		healthCheckType:    awssdk.StringValue(group.HealthCheckType),
+		availabilityZones:  strings.Join(awssdk.StringValueSlice(group.AvailabilityZones), "\n"),
+		suspendedProcesses: len(group.SuspendedProcesses),
+		status:             NO_VALUE,
+		createdTime:        tz.AdaptTimezone(group.CreatedTime),
+	}
+	// status is set only while group is being deleted
+	if group.Status != nil {
+		summary.status = *group.Status
+	}
+	for _, instance := range group.Instances {
+		if awssdk.StringValue(instance.LifecycleState) == autoscaling.LifecycleStateInService {
+			summary.inService++
+		}
+	}
+	return summary
+}
+
+// launchTemplateOf return launch template with version or launch configuration of group
+func launchTemplateOf(group *autoscaling.Group) string {
+	template := group.LaunchTemplate
+	if template == nil && group.MixedInstancesPolicy != nil && group.MixedInstancesPolicy.LaunchTemplate != nil {
+		template = group.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification
+	}
+	if template != nil {
+		return formatLaunchTemplate(template)
+	}
+	if group.LaunchConfigurationName != nil {
+		return fmt.Sprintf("%s(launch configuration)", *group.LaunchConfigurationName)
+	}
+	return NO_VALUE
+}
+
+func formatLaunchTemplate(template *autoscaling.LaunchTemplateSpecification) string {
+	name := awssdk.StringValue(template.LaunchTemplateName)
+	if len(name) == 0 {
+		name = awssdk.StringValue(template.LaunchTemplateId)
+	}
+	return fmt.Sprintf("%s:%s", name, awssdk.StringValue(template.Version))
+}
+
+func newAutoScalingGroupInstance(instance *autoscaling.Instance) *autoScalingGroupInstance {
+	o := &autoScalingGroupInstance{
+		id:             instance.InstanceId,
+		typee:          awssdk.StringValue(instance.InstanceType),
+		az:             awssdk.StringValue(instance.AvailabilityZone),
+		healthStatus:   awssdk.StringValue(instance.HealthStatus),
+		lifecycleState: awssdk.StringValue(instance.LifecycleState),
+		launchTemplate: NO_VALUE,
+		protected:      awssdk.BoolValue(instance.ProtectedFromScaleIn),
+	}
+	if instance.LaunchTemplate != nil {
+		o.launchTemplate = formatLaunchTemplate(instance.LaunchTemplate)
+	} else if instance.LaunchConfigurationName != nil {
+		o.launchTemplate = fmt.Sprintf("%s(launch configuration)", *instance.LaunchConfigurationName)
+	}
+	return o
+}
+
+func newScalingActivity(activity *autoscaling.Activity, tz *ctltime.Timezone) *scalingActivity {
+	return &scalingActivity{
+		startTime:   tz.AdaptTimezone(activity.StartTime),
+		status:      awssdk.StringValue(activity.StatusCode),
+		description: awssdk.StringValue(activity.Description),
+		cause:       awssdk.StringValue(activity.Cause),
+	}
+}
+
+func newLaunchConfigurationSummary(config *autoscaling.LaunchConfiguration, usedBy []string, tz *ctltime.Timezone) *launchConfigurationSummary {
+	summary := &launchConfigurationSummary{
+		name:           config.LaunchConfigurationName,
+		imageId:        awssdk.StringValue(config.ImageId),
+		instanceType:   awssdk.StringValue(config.InstanceType),
+		keyName:        NO_VALUE,
+		securityGroups: awssdk.StringValueSlice(config.SecurityGroups),
+		spotPrice:      NO_VALUE,
+		usedBy:         usedBy,
+		createdTime:    tz.AdaptTimezone(config.CreatedTime),
+	}
+	if config.KeyName != nil && len(*config.KeyName) != 0 {
+		summary.keyName = *config.KeyName
+	}
+	if config.SpotPrice != nil {
+		summary.spotPrice = *config.SpotPrice
+	}
+	return summary
+}
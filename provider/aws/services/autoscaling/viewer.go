@@ -0,0 +1,201 @@
+package autoscaling
+
+import (
+	"cloudctl/viewer"
+	"fmt"
+	"strings"
+)
+
+var (
+	autoScalingGroupListTableHeader = viewer.Row{
+		"Name",
+		"Desired",
+		"Min",
+		"Max",
+		"InService",
+		"LaunchTemplate",
+		"HealthCheck",
+		"Az",
+		"SuspendedProcesses",
+		"Status",
+		"CreatedTime",
+	}
+	// columns hidden unless --wide
+	autoScalingGroupListWideColumns     = []string{"HealthCheck", "Status", "CreatedTime"}
+	autoScalingGroupInstanceTableHeader = viewer.Row{
+		"Id",
+		"Type",
+		"Az",
+		"Health",
+		"Lifecycle",
+		"LaunchTemplate",
+		"ProtectedFromScaleIn",
+	}
+	suspendedProcessTableHeader = viewer.Row{
+		"Process",
+		"Reason",
+	}
+	scalingActivityTableHeader = viewer.Row{
+		"StartTime",
+		"Status",
+		"Description",
+		"Cause",
+	}
+	launchConfigurationListTableHeader = viewer.Row{
+		"Name",
+		"ImageId",
+		"InstanceType",
+		"KeyName",
+		"SecurityGroups",
+		"SpotPrice",
+		"UsedBy",
+		"CreatedTime",
+	}
+	autoScalingGroupNetworkTableHeader = viewer.Row{
+		"Subnets",
+		"TargetGroups",
+	}
+)
+
+func autoScalingGroupListViewer(o interface{}) viewer.Viewer {
+	data := o.(*autoScalingGroupListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("Auto Scaling Groups")
+	tViewer.AddHeader(autoScalingGroupListTableHeader)
+	tViewer.SetWideColumns(autoScalingGroupListWideColumns...)
+	for _, group := range data.groups {
+		tViewer.AddFields(autoScalingGroupSummaryFields(group))
+	}
+	return tViewer
+}
+
+func launchConfigurationListViewer(o interface{}) viewer.Viewer {
+	data := o.(*launchConfigurationListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("Launch Configurations")
+	tViewer.AddHeader(launchConfigurationListTableHeader)
+	for _, config := range data.configs {
+		securityGroups, usedBy := NO_VALUE, NO_VALUE
+		if len(config.securityGroups) != 0 {
+			securityGroups = strings.Join(config.securityGroups, "\n")
+		}
+		if len(config.usedBy) != 0 {
+			usedBy = strings.Join(config.usedBy, "\n")
+		}
+		tViewer.AddFields(viewer.Fields{
+			"Name":           *config.name,
+			"ImageId":        config.imageId,
+			"InstanceType":   config.instanceType,
+			"KeyName":        config.keyName,
+			"SecurityGroups": securityGroups,
+			"SpotPrice":      config.spotPrice,
+			"UsedBy":         usedBy,
+			"CreatedTime":    config.createdTime,
+		})
+	}
+	return tViewer
+}
+
+func autoScalingGroupInfoViewer(o interface{}) viewer.Viewer {
+	data := o.(*autoScalingGroupDefinition)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	cTviewer := viewer.NewCompoundViewer()
+	summaryViewer := viewer.NewTableViewer()
+	summaryViewer.SetTitle("Summary")
+	summaryViewer.AddHeader(autoScalingGroupListTableHeader)
+	summaryViewer.AddFields(autoScalingGroupSummaryFields(data.summary))
+	cTviewer.AddViewer(summaryViewer)
+
+	networkViewer := viewer.NewTableViewer()
+	networkViewer.SetTitle("Network")
+	networkViewer.AddHeader(autoScalingGroupNetworkTableHeader)
+	targetGroups := NO_VALUE
+	if len(data.targetGroups) != 0 {
+		targetGroups = strings.Join(data.targetGroups, "\n")
+	}
+	subnets := NO_VALUE
+	if len(data.vpcZoneIdentifier) != 0 {
+		subnets = strings.ReplaceAll(data.vpcZoneIdentifier, ",", "\n")
+	}
+	networkViewer.AddFields(viewer.Fields{"Subnets": subnets, "TargetGroups": targetGroups})
+	cTviewer.AddViewer(networkViewer)
+
+	instanceViewer := viewer.NewTableViewer()
+	instanceViewer.SetTitle(fmt.Sprintf("Instances (%d)", len(data.instances)))
+	instanceViewer.AddHeader(autoScalingGroupInstanceTableHeader)
+	for _, instance := range data.instances {
+		instanceViewer.AddFields(viewer.Fields{
+			"Id":                   *instance.id,
+			"Type":                 instance.typee,
+			"Az":                   instance.az,
+			"Health":               instance.healthStatus,
+			"Lifecycle":            instance.lifecycleState,
+			"LaunchTemplate":       instance.launchTemplate,
+			"ProtectedFromScaleIn": instance.protected,
+		})
+	}
+	cTviewer.AddViewer(instanceViewer)
+
+	processViewer := viewer.NewTableViewer()
+	processViewer.SetTitle("Suspended Processes")
+	processViewer.AddHeader(suspendedProcessTableHeader)
+	for _, process := range data.suspendedProcesses {
+		processViewer.AddFields(viewer.Fields{"Process": process.name, "Reason": process.reason})
+	}
+	cTviewer.AddViewer(processViewer)
+
+	if data.activitiesErr != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.activitiesErr.ErrorType)
+		erroViewer.SetErrorMessage(data.activitiesErr.Err.Error())
+		cTviewer.AddViewer(erroViewer)
+		return cTviewer
+	}
+	activityViewer := viewer.NewTableViewer()
+	activityViewer.SetTitle("Recent Scaling Activities")
+	activityViewer.AddHeader(scalingActivityTableHeader)
+	for _, activity := range data.activities {
+		activityViewer.AddFields(viewer.Fields{
+			"StartTime":   *activity.startTime,
+			"Status":      activity.status,
+			"Description": activity.description,
+			"Cause":       activity.cause,
+		})
+	}
+	cTviewer.AddViewer(activityViewer)
+	return cTviewer
+}
+
+func autoScalingGroupSummaryFields(group *autoScalingGroupSummary) viewer.Fields {
+	return viewer.Fields{
+		"Name":               *group.name,
+		"Desired":            group.desired,
+		"Min":                group.min,
+		"Max":                group.max,
+		"InService":          fmt.Sprintf("%d/%d", group.inService, group.instances),
+		"LaunchTemplate":     group.launchTemplate,
+		"HealthCheck":        group.healthCheckType,
+		"Az":                 group.availabilityZones,
+		"SuspendedProcesses": group.suspendedProcesses,
+		"Status":             group.status,
+		"CreatedTime":        *group.createdTime,
+	}
+}
@@ -0,0 +1,200 @@
+package ec2
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+type Severity int
+
+const (
+	SEVERITY_CRITICAL Severity = iota
+	SEVERITY_HIGH
+	SEVERITY_MEDIUM
+	SEVERITY_LOW
+)
+
+var (
+	severityNames = map[Severity]string{
+		SEVERITY_CRITICAL: "CRITICAL",
+		SEVERITY_HIGH:     "HIGH",
+		SEVERITY_MEDIUM:   "MEDIUM",
+		SEVERITY_LOW:      "LOW",
+	}
+	// remote administration ports which must never be open to internet
+	adminPorts = map[int64]string{
+		22:   "SSH",
+		3389: "RDP",
+	}
+	databasePorts = map[int64]string{
+		1433:  "MSSQL",
+		1521:  "Oracle",
+		3306:  "MySQL",
+		5432:  "PostgreSQL",
+		5984:  "CouchDB",
+		6379:  "Redis",
+		9042:  "Cassandra",
+		9200:  "Elasticsearch",
+		11211: "Memcached",
+		27017: "MongoDB",
+	}
+	// ports expected to be open to internet
+	webPorts = map[int64]bool{
+		80:  true,
+		443: true,
+	}
+	internetCidrs = map[string]bool{
+		"0.0.0.0/0": true,
+		"::/0":      true,
+	}
+	// RFC1918 & RFC4193 ranges, traffic from them isn't internet exposure
+	privateCidrs = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+)
+
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+type securityGroupFinding struct {
+	severity  Severity
+	region    string
+	sgId      string
+	direction string
+	portRange string
+	protocol  string
+	source    string
+	finding   string
+	// instances (or network interfaces not attached to any instance) using the group
+	exposed []string
+}
+
+// auditSecurityGroup evaluate ingress & egress rules of sg, attachedTo are resources using the group
+// auditSecurityGroup unrestricted egress is reported only with egress, as every group allows it by default
+func auditSecurityGroup(region string, sg *ec2.SecurityGroup, attachedTo []string, egress bool) []*securityGroupFinding {
+	findings := []*securityGroupFinding{}
+	sgIdWithName := fmt.Sprintf("%s(%s)", *sg.GroupId, *sg.GroupName)
+	newFinding := func(severity Severity, direction string, permission *ec2.IpPermission, source, finding string) *securityGroupFinding {
+		portRange, protocol := permissionPortRange(permission)
+		return &securityGroupFinding{
+			severity:  severity,
+			region:    region,
+			sgId:      sgIdWithName,
+			direction: direction,
+			portRange: portRange,
+			protocol:  protocol,
+			source:    source,
+			finding:   finding,
+			exposed:   attachedTo,
+		}
+	}
+
+	for _, permission := range sg.IpPermissions {
+		for _, source := range permissionCidrs(permission) {
+			if internetCidrs[source] {
+				if severity, finding, ok := auditInternetIngress(permission); ok {
+					findings = append(findings, newFinding(severity, "Ingress", permission, source, finding))
+				}
+			} else if *permission.IpProtocol == "-1" && isPrivateCidr(source) {
+				findings = append(findings, newFinding(SEVERITY_LOW, "Ingress", permission, source, "all traffic allowed from private CIDR"))
+			} else if *permission.IpProtocol == "-1" {
+				findings = append(findings, newFinding(SEVERITY_MEDIUM, "Ingress", permission, source, "all traffic allowed from public CIDR"))
+			}
+		}
+	}
+	for _, permission := range sg.IpPermissionsEgress {
+		for _, destination := range permissionCidrs(permission) {
+			if egress && internetCidrs[destination] && *permission.IpProtocol == "-1" {
+				findings = append(findings, newFinding(SEVERITY_LOW, "Egress", permission, destination, "unrestricted egress to internet"))
+			}
+		}
+	}
+	// default group can't be deleted, so it isn't reported as unused
+	if len(attachedTo) == 0 && *sg.GroupName != "default" {
+		findings = append(findings, &securityGroupFinding{
+			severity:  SEVERITY_LOW,
+			region:    region,
+			sgId:      sgIdWithName,
+			direction: NO_VALUE,
+			portRange: NO_VALUE,
+			protocol:  NO_VALUE,
+			source:    NO_VALUE,
+			finding:   "unused security group",
+		})
+	}
+	return findings
+}
+
+// auditInternetIngress return severity of a permission open to internet, false if it is acceptable
+func auditInternetIngress(permission *ec2.IpPermission) (Severity, string, bool) {
+	if *permission.IpProtocol == "-1" {
+		return SEVERITY_CRITICAL, "all traffic open to internet", true
+	}
+	protocol := strings.ToLower(*permission.IpProtocol)
+	if protocol != "tcp" && protocol != "udp" && protocol != "6" && protocol != "17" {
+		return SEVERITY_MEDIUM, fmt.Sprintf("protocol %s open to internet", *permission.IpProtocol), true
+	}
+	from, to := *permission.FromPort, *permission.ToPort
+	exposedServices := []string{}
+	for _, services := range []map[int64]string{adminPorts, databasePorts} {
+		for port, service := range services {
+			if from <= port && port <= to {
+				exposedServices = append(exposedServices, fmt.Sprintf("%s(%d)", service, port))
+			}
+		}
+	}
+	if len(exposedServices) != 0 {
+		sort.Strings(exposedServices)
+		return SEVERITY_CRITICAL, fmt.Sprintf("%s open to internet", strings.Join(exposedServices, ", ")), true
+	}
+	if from != to {
+		return SEVERITY_HIGH, "port range open to internet", true
+	}
+	if webPorts[from] {
+		return 0, "", false
+	}
+	return SEVERITY_MEDIUM, "non-web port open to internet", true
+}
+
+// isPrivateCidr check cidr is fully within a private range
+func isPrivateCidr(cidr string) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	ones, bits := network.Mask.Size()
+	for _, private := range privateCidrs {
+		_, privateNetwork, _ := net.ParseCIDR(private)
+		privateOnes, privateBits := privateNetwork.Mask.Size()
+		if bits == privateBits && ones >= privateOnes && privateNetwork.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func permissionCidrs(permission *ec2.IpPermission) []string {
+	cidrs := []string{}
+	for _, ipRange := range permission.IpRanges {
+		cidrs = append(cidrs, *ipRange.CidrIp)
+	}
+	for _, ipRange := range permission.Ipv6Ranges {
+		cidrs = append(cidrs, *ipRange.CidrIpv6)
+	}
+	return cidrs
+}
+
+func sortFindings(findings []*securityGroupFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].severity != findings[j].severity {
+			return findings[i].severity < findings[j].severity
+		}
+		if findings[i].region != findings[j].region {
+			return findings[i].region < findings[j].region
+		}
+		return findings[i].sgId < findings[j].sgId
+	})
+}
@@ -0,0 +1,191 @@
+package ec2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func permission(protocol string, from, to int64, cidrs ...string) *ec2.IpPermission {
+	p := &ec2.IpPermission{IpProtocol: aws.String(protocol)}
+	if protocol != "-1" {
+		p.FromPort, p.ToPort = aws.Int64(from), aws.Int64(to)
+	}
+	for _, cidr := range cidrs {
+		if strings.Contains(cidr, ":") {
+			p.Ipv6Ranges = append(p.Ipv6Ranges, &ec2.Ipv6Range{CidrIpv6: aws.String(cidr)})
+		} else {
+			p.IpRanges = append(p.IpRanges, &ec2.IpRange{CidrIp: aws.String(cidr)})
+		}
+	}
+	return p
+}
+
+func TestAuditInternetIngress(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission *ec2.IpPermission
+		severity   Severity
+		finding    string
+		reported   bool
+	}{
+		{"all traffic", permission("-1", 0, 0, "0.0.0.0/0"), SEVERITY_CRITICAL, "all traffic open to internet", true},
+		{"ssh", permission("tcp", 22, 22, "0.0.0.0/0"), SEVERITY_CRITICAL, "SSH(22) open to internet", true},
+		{"range with admin & database ports", permission("6", 0, 3389, "0.0.0.0/0"), SEVERITY_CRITICAL, "MSSQL(1433), MySQL(3306), Oracle(1521), RDP(3389), SSH(22) open to internet", true},
+		{"port range", permission("tcp", 8000, 8100, "0.0.0.0/0"), SEVERITY_HIGH, "port range open to internet", true},
+		{"https", permission("tcp", 443, 443, "0.0.0.0/0"), 0, "", false},
+		{"non-web port", permission("udp", 53, 53, "0.0.0.0/0"), SEVERITY_MEDIUM, "non-web port open to internet", true},
+		{"icmp", permission("icmp", -1, -1, "0.0.0.0/0"), SEVERITY_MEDIUM, "protocol icmp open to internet", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			severity, finding, reported := auditInternetIngress(test.permission)
+			if reported != test.reported {
+				t.Fatalf("reported = %v, want %v", reported, test.reported)
+			}
+			if reported && (severity != test.severity || finding != test.finding) {
+				t.Errorf("got %s %q, want %s %q", severity, finding, test.severity, test.finding)
+			}
+		})
+	}
+}
+
+func TestAuditSecurityGroup(t *testing.T) {
+	type expected struct {
+		severity Severity
+		finding  string
+	}
+	attached := []string{"i-1"}
+	tests := []struct {
+		name       string
+		sg         *ec2.SecurityGroup
+		attachedTo []string
+		egress     bool
+		want       []expected
+	}{
+		{
+			name:       "ssh open to internet",
+			sg:         securityGroup("sg-1", []*ec2.IpPermission{permission("tcp", 22, 22, "0.0.0.0/0")}, allEgress),
+			attachedTo: attached,
+			want:       []expected{{SEVERITY_CRITICAL, "SSH(22) open to internet"}},
+		},
+		{
+			name:       "https open to ipv4 & ipv6 internet",
+			sg:         securityGroup("sg-1", []*ec2.IpPermission{permission("tcp", 443, 443, "0.0.0.0/0", "::/0")}, allEgress),
+			attachedTo: attached,
+		},
+		{
+			name:       "ssh open to cidr",
+			sg:         securityGroup("sg-1", []*ec2.IpPermission{permission("tcp", 22, 22, "203.0.113.0/24")}, allEgress),
+			attachedTo: attached,
+		},
+		{
+			name:       "all traffic from public cidr",
+			sg:         securityGroup("sg-1", []*ec2.IpPermission{permission("-1", 0, 0, "203.0.113.0/24")}, allEgress),
+			attachedTo: attached,
+			want:       []expected{{SEVERITY_MEDIUM, "all traffic allowed from public CIDR"}},
+		},
+		{
+			name:       "all traffic from private cidrs",
+			sg:         securityGroup("sg-1", []*ec2.IpPermission{permission("-1", 0, 0, "10.0.0.0/16", "172.16.0.0/12", "fd00::/8")}, allEgress),
+			attachedTo: attached,
+			want: []expected{
+				{SEVERITY_LOW, "all traffic allowed from private CIDR"},
+				{SEVERITY_LOW, "all traffic allowed from private CIDR"},
+				{SEVERITY_LOW, "all traffic allowed from private CIDR"},
+			},
+		},
+		{
+			name:       "all traffic from cidr wider than private range",
+			sg:         securityGroup("sg-1", []*ec2.IpPermission{permission("-1", 0, 0, "172.0.0.0/8")}, allEgress),
+			attachedTo: attached,
+			want:       []expected{{SEVERITY_MEDIUM, "all traffic allowed from public CIDR"}},
+		},
+		{
+			name:       "unrestricted egress isn't reported by default",
+			sg:         securityGroup("sg-1", nil, allEgress),
+			attachedTo: attached,
+		},
+		{
+			name:       "unrestricted egress with egress",
+			sg:         securityGroup("sg-1", nil, allEgress),
+			attachedTo: attached,
+			egress:     true,
+			want:       []expected{{SEVERITY_LOW, "unrestricted egress to internet"}},
+		},
+		{
+			name:   "restricted egress with egress",
+			sg:     securityGroup("sg-1", nil, []*ec2.IpPermission{permission("tcp", 443, 443, "0.0.0.0/0")}),
+			egress: true,
+			want:   []expected{{SEVERITY_LOW, "unused security group"}},
+		},
+		{
+			name: "unused group",
+			sg:   securityGroup("sg-1", nil, allEgress),
+			want: []expected{{SEVERITY_LOW, "unused security group"}},
+		},
+		{
+			name: "unused default group",
+			sg:   &ec2.SecurityGroup{GroupId: aws.String("sg-1"), GroupName: aws.String("default"), IpPermissionsEgress: allEgress},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings := auditSecurityGroup("eu-west-1", test.sg, test.attachedTo, test.egress)
+			if len(findings) != len(test.want) {
+				for _, finding := range findings {
+					t.Logf("%s %s %s", finding.severity, finding.source, finding.finding)
+				}
+				t.Fatalf("got %d findings, want %d", len(findings), len(test.want))
+			}
+			for i, finding := range findings {
+				if finding.severity != test.want[i].severity || finding.finding != test.want[i].finding {
+					t.Errorf("finding %d = %s %q, want %s %q", i, finding.severity, finding.finding, test.want[i].severity, test.want[i].finding)
+				}
+			}
+		})
+	}
+}
+
+func TestSortFindings(t *testing.T) {
+	findings := []*securityGroupFinding{
+		{severity: SEVERITY_LOW, region: "eu-west-1", sgId: "sg-1"},
+		{severity: SEVERITY_CRITICAL, region: "us-east-1", sgId: "sg-2"},
+		{severity: SEVERITY_CRITICAL, region: "eu-west-1", sgId: "sg-3"},
+		{severity: SEVERITY_MEDIUM, region: "eu-west-1", sgId: "sg-4"},
+		{severity: SEVERITY_CRITICAL, region: "eu-west-1", sgId: "sg-1"},
+	}
+	sortFindings(findings)
+	want := []string{"sg-1", "sg-3", "sg-2", "sg-4", "sg-1"}
+	for i, finding := range findings {
+		if finding.sgId != want[i] {
+			t.Errorf("finding %d = %s %s %s, want %s", i, finding.severity, finding.region, finding.sgId, want[i])
+		}
+	}
+}
+
+func TestIsPrivateCidr(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want bool
+	}{
+		{"10.1.0.0/16", true},
+		{"10.0.0.0/8", true},
+		{"10.0.0.0/7", false},
+		{"172.31.0.0/16", true},
+		{"172.32.0.0/16", false},
+		{"192.168.1.10/32", true},
+		{"203.0.113.0/24", false},
+		{"0.0.0.0/0", false},
+		{"fd12:3456::/48", true},
+		{"2001:db8::/32", false},
+		{"invalid", false},
+	}
+	for _, test := range tests {
+		if got := isPrivateCidr(test.cidr); got != test.want {
+			t.Errorf("isPrivateCidr(%s) = %v, want %v", test.cidr, got, test.want)
+		}
+	}
+}
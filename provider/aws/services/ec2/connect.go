@@ -0,0 +1,171 @@
+package ec2
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	CONNECT_METHOD_AUTO = "auto"
+	CONNECT_METHOD_SSM  = "ssm"
+	CONNECT_METHOD_SSH  = "ssh"
+
+	CONNECT_IP_AUTO    = "auto"
+	CONNECT_IP_PUBLIC  = "public"
+	CONNECT_IP_PRIVATE = "private"
+
+	SESSION_MANAGER_PLUGIN = "session-manager-plugin"
+
+	DEFAULT_SSH_USER = "ec2-user"
+	SSH_PORT         = 22
+	// how long to wait while probing whether public ip is reachable
+	SSH_PROBE_TIMEOUT = 3 * time.Second
+)
+
+var (
+	// default login user of well known AMIs, matched against image name
+	sshUsersByImageName = []struct {
+		keyword string
+		user    string
+	}{
+		{"ubuntu", "ubuntu"},
+		{"debian", "admin"},
+		{"centos", "centos"},
+		{"fedora", "fedora"},
+		{"bitnami", "bitnami"},
+		{"rocky", "rocky"},
+		{"almalinux", "ec2-user"},
+		{"suse", "ec2-user"},
+		{"rhel", "ec2-user"},
+		{"amzn", "ec2-user"},
+	}
+)
+
+// PortForward is a local port forwarded to host:port as seen from the instance
+type PortForward struct {
+	LocalPort  int
+	Host       string
+	RemotePort int
+}
+
+// ParsePortForward parse forward spec in local_port:host:remote_port format e.g. 5432:localhost:5432
+func ParsePortForward(spec string) (*PortForward, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 3 || len(parts[1]) == 0 {
+		return nil, fmt.Errorf("invalid forward %q, expected local_port:host:remote_port e.g. 5432:localhost:5432", spec)
+	}
+	localPort, err := parsePort(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	remotePort, err := parsePort(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	return &PortForward{LocalPort: localPort, Host: parts[1], RemotePort: remotePort}, nil
+}
+
+func (f *PortForward) String() string {
+	return fmt.Sprintf("%d:%s:%d", f.LocalPort, f.Host, f.RemotePort)
+}
+
+// isLocal return true if forwarded host is the instance itself
+func (f *PortForward) isLocal() bool {
+	return f.Host == "localhost" || f.Host == "127.0.0.1"
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", value)
+	}
+	return port, nil
+}
+
+// sshUser return default login user of instance image, imageName can be empty if image is not found
+func sshUser(instance *ec2.Instance, imageName string) (string, error) {
+	if strings.EqualFold(awssdk.StringValue(instance.Platform), ec2.PlatformValuesWindows) {
+		return "", WindowsSSHNotSupported(*instance.InstanceId)
+	}
+	imageName = strings.ToLower(imageName)
+	for _, candidate := range sshUsersByImageName {
+		if strings.Contains(imageName, candidate.keyword) {
+			return candidate.user, nil
+		}
+	}
+	return DEFAULT_SSH_USER, nil
+}
+
+// sshKeyFile return private key of instance key pair from ~/.ssh, empty if not found so ssh falls back to agent/config
+func sshKeyFile(instance *ec2.Instance) string {
+	keyName := awssdk.StringValue(instance.KeyName)
+	home, err := os.UserHomeDir()
+	if len(keyName) == 0 || err != nil {
+		return ""
+	}
+	for _, name := range []string{keyName + ".pem", keyName} {
+		file := filepath.Join(home, ".ssh", name)
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+	}
+	return ""
+}
+
+// sshAddress choose public or private ip, in auto mode public ip is used only if ssh port is reachable
+func sshAddress(instance *ec2.Instance, ipType string) (string, error) {
+	publicIp, privateIp := awssdk.StringValue(instance.PublicIpAddress), awssdk.StringValue(instance.PrivateIpAddress)
+	switch ipType {
+	case CONNECT_IP_PUBLIC:
+		if len(publicIp) == 0 {
+			return "", NoPublicIp(*instance.InstanceId)
+		}
+		return publicIp, nil
+	case CONNECT_IP_PRIVATE:
+		return privateIp, nil
+	}
+	if len(publicIp) != 0 {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(publicIp, strconv.Itoa(SSH_PORT)), SSH_PROBE_TIMEOUT)
+		if err == nil {
+			conn.Close()
+			return publicIp, nil
+		}
+	}
+	return privateIp, nil
+}
+
+// sshArgs build ssh arguments, with port forwarding no remote command is executed
+func sshArgs(user, address, keyFile string, forward *PortForward) []string {
+	args := []string{}
+	if len(keyFile) != 0 {
+		args = append(args, "-i", keyFile)
+	}
+	if forward != nil {
+		args = append(args, "-N", "-L", forward.String())
+	}
+	return append(args, fmt.Sprintf("%s@%s", user, address))
+}
+
+// ssmDocument return session document & its parameters, no document means interactive shell
+func ssmDocument(forward *PortForward) (string, map[string][]*string) {
+	if forward == nil {
+		return "", nil
+	}
+	parameters := map[string][]*string{
+		"portNumber":      {awssdk.String(strconv.Itoa(forward.RemotePort))},
+		"localPortNumber": {awssdk.String(strconv.Itoa(forward.LocalPort))},
+	}
+	if forward.isLocal() {
+		return "AWS-StartPortForwardingSession", parameters
+	}
+	parameters["host"] = []*string{awssdk.String(forward.Host)}
+	return "AWS-StartPortForwardingSessionToRemoteHost", parameters
+}
@@ -5,3 +5,114 @@ import "fmt"
 func NoInstanceFound() error {
 	return fmt.Errorf("no instance found")
 }
+func NoSecurityGroupFound() error {
+	return fmt.Errorf("no security group found")
+}
+func SecurityGroupNotFound(id string) error {
+	return fmt.Errorf("security group %s not found", id)
+}
+func NoSecurityGroupFinding() error {
+	return fmt.Errorf("no security group finding")
+}
+func CriticalSecurityGroupFindings(count int) error {
+	return fmt.Errorf("%d critical security group finding(s)", count)
+}
+func SecurityGroupAuditIncomplete(regions int) error {
+	return fmt.Errorf("security groups of %d region(s) couldn't be audited", regions)
+}
+func NotReachable(from, to string) error {
+	return fmt.Errorf("%s can't reach %s", from, to)
+}
+func InstanceNotFound(idOrName string) error {
+	return fmt.Errorf("instance %s not found", idOrName)
+}
+func MultipleInstanceFound(name string, count int) error {
+	return fmt.Errorf("%d instances found with name %s, use instance id", count, name)
+}
+func NoVpcFound() error {
+	return fmt.Errorf("no vpc found")
+}
+func VpcNotFound(id string) error {
+	return fmt.Errorf("vpc %s not found", id)
+}
+func NoSubnetFound() error {
+	return fmt.Errorf("no subnet found")
+}
+func NoVolumeFound() error {
+	return fmt.Errorf("no volume found")
+}
+func NoSnapshotFound() error {
+	return fmt.Errorf("no snapshot found")
+}
+func NoOrphanedResourceFound() error {
+	return fmt.Errorf("no orphaned volume or snapshot found")
+}
+func CleanupNotConfirmed() error {
+	return fmt.Errorf("cleanup is not confirmed, nothing is deleted")
+}
+func NoImageFound() error {
+	return fmt.Errorf("no image found")
+}
+func ImageNotFound(id string) error {
+	return fmt.Errorf("image %s not found", id)
+}
+func NoUnusedImageFound() error {
+	return fmt.Errorf("no unused image found")
+}
+func DeregisterNotConfirmed() error {
+	return fmt.Errorf("deregister is not confirmed, nothing is deleted")
+}
+func NoElasticIpFound() error {
+	return fmt.Errorf("no elastic ip found")
+}
+func NoNetworkInterfaceFound() error {
+	return fmt.Errorf("no network interface found")
+}
+func InstanceNotRunning(id, state string) error {
+	return fmt.Errorf("instance %s is %s, only running instance can be connected", id, state)
+}
+func WindowsSSHNotSupported(id string) error {
+	return fmt.Errorf("instance %s is windows, use ssm or RDP instead of ssh", id)
+}
+func NoPublicIp(id string) error {
+	return fmt.Errorf("instance %s has no public ip", id)
+}
+func SessionManagerPluginNotFound() error {
+	return fmt.Errorf("%s not found in PATH, see https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html", SESSION_MANAGER_PLUGIN)
+}
+func InstanceNotManagedBySSM(id string) error {
+	return fmt.Errorf("instance %s is not online in ssm, check ssm agent & instance profile", id)
+}
+func NoConsoleOutput(id string) error {
+	return fmt.Errorf("no console output of instance %s yet, it is available shortly after boot, try --latest on nitro instance", id)
+}
+func NoUserData(id string) error {
+	return fmt.Errorf("instance %s has no user data", id)
+}
+func NoInstanceTypeFound() error {
+	return fmt.Errorf("no instance type found")
+}
+func InstanceTypeNotFound(instanceType string) error {
+	return fmt.Errorf("instance type %s not found", instanceType)
+}
+func NoLaunchTemplateFound() error {
+	return fmt.Errorf("no launch template found")
+}
+func LaunchTemplateNotFound(target string) error {
+	return fmt.Errorf("launch template %s not found", target)
+}
+func LaunchTemplateVersionNotFound(target, version string) error {
+	return fmt.Errorf("version %s of launch template %s not found", version, target)
+}
+func NoLaunchTemplateChange(id string, from, to int64) error {
+	return fmt.Errorf("no difference between version %d and %d of launch template %s", from, to, id)
+}
+func NoSpotRequestFound() error {
+	return fmt.Errorf("no spot instance request found")
+}
+func NoSpotPriceFound() error {
+	return fmt.Errorf("no spot price found, check instance type, availability zone and product")
+}
+func InvalidGroupBy(value string) error {
+	return fmt.Errorf("invalid group by %q, supported input [state,type,az,vpc,subnet,tag:<key>,none]", value)
+}
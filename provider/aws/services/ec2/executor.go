@@ -6,22 +6,26 @@ import (
 	"cloudctl/provider/aws/cli/globals"
 	"cloudctl/time"
 	"strings"
+	gotime "time"
 )
 
-func NewinstanceListCommandExecutor(flag *globals.CLIFlag, filter InstanceListFilter) *executor.CommandExecutor {
-	client := aws.NewClient(flag.Profile, flag.Region, flag.Debug)
+func NewinstanceListCommandExecutor(flag *globals.CLIFlag, filter InstanceListFilter, showNames, showStatus bool, view *InstanceListView) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
 	return &executor.CommandExecutor{
 		Fetcher: &instanceListFetcher{
-			client: client,
-			tz:     time.GetTZ(flag.TZShortIdentifier),
-			filter: filter,
+			client:     client,
+			tz:         time.GetTZ(flag.TZShortIdentifier),
+			filter:     filter,
+			showNames:  showNames,
+			showStatus: showStatus,
+			view:       view,
 		},
 		Viewer: instanceListViewer,
 	}
 }
 
 func NewinstanceDescribeCommandExecutor(flag *globals.CLIFlag, instanceId string) *executor.CommandExecutor {
-	client := aws.NewClient(flag.Profile, flag.Region, flag.Debug)
+	client := aws.NewClient(flag)
 	spaceTrimmedInstanceId := strings.TrimSpace(instanceId)
 	return &executor.CommandExecutor{
 		Fetcher: &instanceDefinitionFetcher{
@@ -32,3 +36,330 @@ func NewinstanceDescribeCommandExecutor(flag *globals.CLIFlag, instanceId string
 		Viewer: instanceInfoViewer,
 	}
 }
+
+func NewSecurityGroupListCommandExecutor(flag *globals.CLIFlag, filter *SecurityGroupListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &securityGroupListFetcher{
+			client: client,
+			filter: filter,
+		},
+		Viewer: securityGroupListViewer,
+	}
+}
+
+func NewSecurityGroupDescribeCommandExecutor(flag *globals.CLIFlag, groupId string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &securityGroupDefinitionFetcher{
+			client: client,
+			id:     strings.TrimSpace(groupId),
+		},
+		Viewer: securityGroupInfoViewer,
+	}
+}
+
+func NewSecurityGroupAuditCommandExecutor(flag *globals.CLIFlag, regions []string, allRegions, egress bool) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &securityGroupAuditFetcher{
+			client:     client,
+			regions:    regions,
+			allRegions: allRegions,
+			egress:     egress,
+		},
+		Viewer: securityGroupAuditViewer,
+	}
+}
+
+func NewReachabilityCommandExecutor(flag *globals.CLIFlag, from, to, protocol string, port int64) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &reachabilityFetcher{
+			client:   client,
+			from:     strings.TrimSpace(from),
+			to:       strings.TrimSpace(to),
+			protocol: protocol,
+			port:     port,
+		},
+		Viewer: reachabilityViewer,
+	}
+}
+
+func NewVpcListCommandExecutor(flag *globals.CLIFlag) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &vpcListFetcher{
+			client: client,
+		},
+		Viewer: vpcListViewer,
+	}
+}
+
+func NewVpcDescribeCommandExecutor(flag *globals.CLIFlag, vpcId string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &vpcDefinitionFetcher{
+			client: client,
+			id:     strings.TrimSpace(vpcId),
+		},
+		Viewer: vpcInfoViewer,
+	}
+}
+
+func NewSubnetListCommandExecutor(flag *globals.CLIFlag, filter *SubnetListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &subnetListFetcher{
+			client: client,
+			filter: filter,
+		},
+		Viewer: subnetListViewer,
+	}
+}
+
+func NewVolumeListCommandExecutor(flag *globals.CLIFlag, filter *VolumeListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &volumeListFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: volumeListViewer,
+	}
+}
+
+func NewVolumeCleanupCommandExecutor(flag *globals.CLIFlag, olderThan gotime.Time, olderThanValue string, delete, autoApprove bool) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &volumeCleanupFetcher{
+			client:         client,
+			tz:             time.GetTZ(flag.TZShortIdentifier),
+			olderThan:      olderThan,
+			olderThanValue: olderThanValue,
+			delete:         delete,
+		},
+		Viewer:     volumeCleanupViewer,
+		PlanViewer: volumeCleanupViewer,
+		Confirm:    aws.Confirmer(autoApprove),
+	}
+}
+
+func NewSnapshotListCommandExecutor(flag *globals.CLIFlag, filter *SnapshotListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &snapshotListFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: snapshotListViewer,
+	}
+}
+
+func NewSnapshotCreateCommandExecutor(flag *globals.CLIFlag, instance, description string, tags map[string]string, excludeBootVolume bool) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &snapshotCreateFetcher{
+			client:            client,
+			tz:                time.GetTZ(flag.TZShortIdentifier),
+			instance:          strings.TrimSpace(instance),
+			description:       description,
+			tags:              tags,
+			excludeBootVolume: excludeBootVolume,
+		},
+		Viewer: snapshotCreateViewer,
+	}
+}
+
+func NewImageListCommandExecutor(flag *globals.CLIFlag, filter *ImageListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &imageListFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: imageListViewer,
+	}
+}
+
+func NewImageDescribeCommandExecutor(flag *globals.CLIFlag, imageId string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &imageDefinitionFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			id:     strings.TrimSpace(imageId),
+		},
+		Viewer: imageInfoViewer,
+	}
+}
+
+func NewImageUnusedCommandExecutor(flag *globals.CLIFlag, filter *ImageListFilter, olderThan string, deregister, autoApprove bool) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &imageUnusedFetcher{
+			client:     client,
+			tz:         time.GetTZ(flag.TZShortIdentifier),
+			filter:     filter,
+			olderThan:  olderThan,
+			deregister: deregister,
+		},
+		Viewer:     imageUnusedViewer,
+		PlanViewer: imageUnusedViewer,
+		Confirm:    aws.Confirmer(autoApprove),
+	}
+}
+
+func NewElasticIpListCommandExecutor(flag *globals.CLIFlag, unassociated bool) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &elasticIpListFetcher{
+			client:       client,
+			unassociated: unassociated,
+		},
+		Viewer: elasticIpListViewer,
+	}
+}
+
+func NewNetworkInterfaceListCommandExecutor(flag *globals.CLIFlag, filter *NetworkInterfaceListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &networkInterfaceListFetcher{
+			client: client,
+			filter: filter,
+		},
+		Viewer: networkInterfaceListViewer,
+	}
+}
+
+func NewConnectCommandExecutor(flag *globals.CLIFlag, target, method, ipType, user, keyFile string, forward *PortForward) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &connectFetcher{
+			client:  client,
+			target:  strings.TrimSpace(target),
+			method:  method,
+			ipType:  ipType,
+			user:    user,
+			keyFile: keyFile,
+			forward: forward,
+		},
+		Viewer: connectViewer,
+	}
+}
+
+func NewConsoleOutputCommandExecutor(flag *globals.CLIFlag, target string, latest bool, tail int, screenshotFile string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &consoleOutputFetcher{
+			client:         client,
+			tz:             time.GetTZ(flag.TZShortIdentifier),
+			target:         strings.TrimSpace(target),
+			latest:         latest,
+			tail:           tail,
+			screenshotFile: screenshotFile,
+		},
+		Viewer: consoleOutputViewer,
+	}
+}
+
+func NewUserDataCommandExecutor(flag *globals.CLIFlag, target string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &userDataFetcher{
+			client: client,
+			target: strings.TrimSpace(target),
+		},
+		Viewer: userDataViewer,
+	}
+}
+
+func NewInstanceTypeListCommandExecutor(flag *globals.CLIFlag, filter *InstanceTypeListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &instanceTypeListFetcher{
+			client: client,
+			filter: filter,
+		},
+		Viewer: instanceTypeListViewer,
+	}
+}
+
+func NewInstanceTypeCompareCommandExecutor(flag *globals.CLIFlag, instanceTypes []string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &instanceTypeCompareFetcher{
+			client:        client,
+			instanceTypes: instanceTypes,
+		},
+		Viewer: instanceTypeCompareViewer,
+	}
+}
+
+func NewLaunchTemplateListCommandExecutor(flag *globals.CLIFlag, filter *LaunchTemplateListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &launchTemplateListFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: launchTemplateListViewer,
+	}
+}
+
+func NewLaunchTemplateDescribeCommandExecutor(flag *globals.CLIFlag, target, version string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	if len(version) == 0 {
+		version = DEFAULT_LAUNCH_TEMPLATE_VERSION
+	}
+	return &executor.CommandExecutor{
+		Fetcher: &launchTemplateDefinitionFetcher{
+			client:  client,
+			tz:      time.GetTZ(flag.TZShortIdentifier),
+			target:  strings.TrimSpace(target),
+			version: version,
+		},
+		Viewer: launchTemplateInfoViewer,
+	}
+}
+
+func NewLaunchTemplateDiffCommandExecutor(flag *globals.CLIFlag, target, from, to string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &launchTemplateDiffFetcher{
+			client: client,
+			target: strings.TrimSpace(target),
+			from:   from,
+			to:     to,
+		},
+		Viewer: launchTemplateDiffViewer,
+	}
+}
+
+func NewSpotRequestListCommandExecutor(flag *globals.CLIFlag, filter *SpotRequestListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &spotRequestListFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: spotRequestListViewer,
+	}
+}
+
+func NewSpotPriceHistoryCommandExecutor(flag *globals.CLIFlag, filter *SpotPriceHistoryFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+	return &executor.CommandExecutor{
+		Fetcher: &spotPriceHistoryFetcher{
+			client: client,
+			tz:     time.GetTZ(flag.TZShortIdentifier),
+			filter: filter,
+		},
+		Viewer: spotPriceHistoryViewer,
+	}
+}
@@ -4,18 +4,75 @@ import (
 	"cloudctl/provider/aws"
 	"cloudctl/time"
 	"cloudctl/viewer"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strings"
 	"sync"
+	gotime "time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ssm"
 )
 
 type instanceListFetcher struct {
+	client     *aws.Client
+	tz         *time.Timezone
+	filter     InstanceListFilter
+	showNames  bool
+	showStatus bool
+	view       *InstanceListView
+}
+
+type instanceTypeListFetcher struct {
+	client *aws.Client
+	filter *InstanceTypeListFilter
+}
+
+type instanceTypeCompareFetcher struct {
+	client        *aws.Client
+	instanceTypes []string
+}
+
+type launchTemplateListFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	filter *LaunchTemplateListFilter
+}
+
+type launchTemplateDefinitionFetcher struct {
+	client  *aws.Client
+	tz      *time.Timezone
+	target  string
+	version string
+}
+
+type spotRequestListFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	filter *SpotRequestListFilter
+}
+
+type spotPriceHistoryFetcher struct {
 	client *aws.Client
 	tz     *time.Timezone
-	filter InstanceListFilter
+	filter *SpotPriceHistoryFilter
+}
+
+type launchTemplateDiffFetcher struct {
+	client *aws.Client
+	target string
+	from   string
+	to     string
 }
 
 type instanceDefinitionFetcher struct {
@@ -24,22 +81,209 @@ type instanceDefinitionFetcher struct {
 	id     *string
 }
 
+type securityGroupListFetcher struct {
+	client *aws.Client
+	filter *SecurityGroupListFilter
+}
+
+type securityGroupDefinitionFetcher struct {
+	client *aws.Client
+	id     string
+}
+
+type securityGroupAuditFetcher struct {
+	client     *aws.Client
+	regions    []string
+	allRegions bool
+	egress     bool
+}
+
+type reachabilityFetcher struct {
+	client   *aws.Client
+	from     string
+	to       string
+	protocol string
+	port     int64
+}
+
+type vpcListFetcher struct {
+	client *aws.Client
+}
+
+type vpcDefinitionFetcher struct {
+	client *aws.Client
+	id     string
+}
+
+type subnetListFetcher struct {
+	client *aws.Client
+	filter *SubnetListFilter
+}
+
+type volumeListFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	filter *VolumeListFilter
+}
+
+type snapshotListFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	filter *SnapshotListFilter
+}
+
+type snapshotCreateFetcher struct {
+	client            *aws.Client
+	tz                *time.Timezone
+	instance          string
+	description       string
+	tags              map[string]string
+	excludeBootVolume bool
+}
+
+type volumeCleanupFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	// zero means no limit
+	olderThan      gotime.Time
+	olderThanValue string
+	delete         bool
+}
+
+type imageListFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	filter *ImageListFilter
+}
+
+type imageDefinitionFetcher struct {
+	client *aws.Client
+	tz     *time.Timezone
+	id     string
+}
+
+type imageUnusedFetcher struct {
+	client     *aws.Client
+	tz         *time.Timezone
+	filter     *ImageListFilter
+	olderThan  string
+	deregister bool
+}
+
+type elasticIpListFetcher struct {
+	client       *aws.Client
+	unassociated bool
+}
+
+type networkInterfaceListFetcher struct {
+	client *aws.Client
+	filter *NetworkInterfaceListFilter
+}
+
+type connectFetcher struct {
+	client  *aws.Client
+	target  string
+	method  string
+	ipType  string
+	user    string
+	keyFile string
+	forward *PortForward
+}
+
+type consoleOutputFetcher struct {
+	client         *aws.Client
+	tz             *time.Timezone
+	target         string
+	latest         bool
+	tail           int
+	screenshotFile string
+}
+
+type userDataFetcher struct {
+	client *aws.Client
+	target string
+}
+
 func (f instanceListFetcher) Fetch() interface{} {
 
 	apiOutput, err := fetchInstanceList(f.client, f.filter)
-	instancesByState := make(map[string][]*instanceSummary)
 	if len(*apiOutput) == 0 {
 		errorInfo := aws.NewErrorInfo(NoInstanceFound(), viewer.INFO, nil)
-		return &instanceListOutput{instancesByState: instancesByState, err: errorInfo}
+		return &instanceListOutput{err: errorInfo}
+	}
+	// impaired filter needs status of instances
+	showStatus := f.showStatus || f.filter.impaired
+	statuses := map[string]*ec2.InstanceStatus{}
+	if showStatus && err == nil {
+		instanceIds := []string{}
+		for _, o := range *apiOutput {
+			instanceIds = append(instanceIds, *o.InstanceId)
+		}
+		statuses, err = fetchInstanceStatuses(f.client, instanceIds)
 	}
+	now := gotime.Now()
+	instances := []*instanceSummary{}
 	for _, o := range *apiOutput {
-		instancesByState[*o.State.Name] = append(instancesByState[*o.State.Name], newInstanceSummary(o, f.tz))
+		summary := newInstanceSummary(o, f.tz)
+		if showStatus {
+			summary.status = newInstanceStatus(o, statuses[*o.InstanceId], f.tz, now)
+			if !f.filter.applyStatusFilter(summary.status) {
+				continue
+			}
+		}
+		instances = append(instances, summary)
 	}
 	if err != nil {
 		errorInfo := aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
-		return &instanceListOutput{instancesByState: instancesByState, err: errorInfo}
+		return &instanceListOutput{err: errorInfo}
+	}
+	if len(instances) == 0 {
+		errorInfo := aws.NewErrorInfo(NoInstanceFound(), viewer.INFO, nil)
+		return &instanceListOutput{err: errorInfo}
+	}
+	if f.showNames {
+		if err := setNetworkNames(f.client, instances); err != nil {
+			// names are cosmetic, instances are listed with bare ids
+			log.Default().Printf("vpc & subnet names aren't available, %v\n", aws.AWSError(err))
+		}
+	}
+	if f.view.summary {
+		if err := setInstanceMemory(f.client, instances); err != nil {
+			errorInfo := aws.NewErrorInfo(aws.AWSError(err), viewer.WARN, nil)
+			return &instanceListOutput{err: errorInfo}
+		}
+	}
+	f.view.sortInstances(instances)
+	return &instanceListOutput{
+		groups:     f.view.groupInstances(instances),
+		groupBy:    f.view.groupBy,
+		summary:    f.view.summary,
+		showStatus: showStatus,
+	}
+}
+
+// setInstanceMemory set memory of instances from their instance type
+func setInstanceMemory(client *aws.Client, instances []*instanceSummary) error {
+	types := map[string]bool{}
+	for _, instance := range instances {
+		types[*instance.typee] = true
+	}
+	instanceTypes := make([]string, 0, len(types))
+	for instanceType := range types {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	infos, err := fetchInstanceTypes(client, instanceTypes, nil)
+	if err != nil {
+		return err
 	}
-	return &instanceListOutput{instancesByState: instancesByState, err: nil}
+	memory := map[string]float64{}
+	for _, info := range infos {
+		memory[*info.InstanceType] = newInstanceTypeSummary(info).memory
+	}
+	for _, instance := range instances {
+		instance.memory = memory[*instance.typee]
+	}
+	return nil
 }
 
 func (f instanceDefinitionFetcher) Fetch() interface{} {
@@ -50,6 +294,99 @@ func (f instanceDefinitionFetcher) Fetch() interface{} {
 	return definition
 }
 
+// setNetworkNames render vpc & subnet of instances as "id (name)" when they have Name tag, ids are kept on error
+func setNetworkNames(client *aws.Client, instances []*instanceSummary) error {
+	vpcIds, subnetIds := map[string]bool{}, map[string]bool{}
+	for _, instance := range instances {
+		if *instance.vpcId != NO_VALUE {
+			vpcIds[*instance.vpcId] = true
+		}
+		if *instance.subnetId != NO_VALUE {
+			subnetIds[*instance.subnetId] = true
+		}
+	}
+	if len(vpcIds) == 0 {
+		return nil
+	}
+	names := map[string]string{}
+	// at most 200 values per filter
+	const batchSize = 200
+	for _, batch := range batchValues(vpcIds, batchSize) {
+		vpcs, err := fetchVpcs(client, []*ec2.Filter{newFilter(vpc_id_key, batch...)})
+		if err != nil {
+			return err
+		}
+		for _, vpc := range vpcs {
+			names[*vpc.VpcId] = nameTag(vpc.Tags)
+		}
+	}
+	for _, batch := range batchValues(subnetIds, batchSize) {
+		subnets, err := fetchSubnets(client, []*ec2.Filter{newFilter(subnet_id_key, batch...)})
+		if err != nil {
+			return err
+		}
+		for _, subnet := range subnets {
+			names[*subnet.SubnetId] = nameTag(subnet.Tags)
+		}
+	}
+	withName := func(id *string) *string {
+		if name, ok := names[*id]; ok && name != NO_VALUE {
+			value := fmt.Sprintf("%s (%s)", *id, name)
+			return &value
+		}
+		return id
+	}
+	for _, instance := range instances {
+		instance.vpcId = withName(instance.vpcId)
+		instance.subnetId = withName(instance.subnetId)
+	}
+	return nil
+}
+
+// batchValues split set of values into sorted batches of at most size values
+func batchValues(values map[string]bool, size int) [][]string {
+	sorted := make([]string, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Strings(sorted)
+	batches := [][]string{}
+	for start := 0; start < len(sorted); start += size {
+		end := start + size
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		batches = append(batches, sorted[start:end])
+	}
+	return batches
+}
+
+// fetchInstanceStatuses return status checks & scheduled events by instance id, stopped instances are included
+func fetchInstanceStatuses(client *aws.Client, instanceIds []string) (map[string]*ec2.InstanceStatus, error) {
+	// at most 100 instance ids per request
+	const batchSize = 100
+	statuses := map[string]*ec2.InstanceStatus{}
+	for start := 0; start < len(instanceIds); start += batchSize {
+		end := start + batchSize
+		if end > len(instanceIds) {
+			end = len(instanceIds)
+		}
+		err := client.EC2.DescribeInstanceStatusPages(&ec2.DescribeInstanceStatusInput{
+			InstanceIds:         awssdk.StringSlice(instanceIds[start:end]),
+			IncludeAllInstances: awssdk.Bool(true),
+		}, func(page *ec2.DescribeInstanceStatusOutput, lastPage bool) bool {
+			for _, status := range page.InstanceStatuses {
+				statuses[*status.InstanceId] = status
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return statuses, nil
+}
+
 func fetchInstanceList(client *aws.Client, instanceListFilter InstanceListFilter) (*[]*ec2.Instance, error) {
 	var fetch func(filter []*ec2.Filter, nextMarker string, instances *[]*ec2.Instance, client *aws.Client) error
 
@@ -89,8 +426,8 @@ func fetchInstanceList(client *aws.Client, instanceListFilter InstanceListFilter
 func fetchInstanceDefinition(instanceId *string, tz *time.Timezone, client *aws.Client) (*instanceDefinition, error) {
 	instanceDefinition := newInstanceDefinition()
 	networkinterfaces := []*instanceNetworkinterface{}
+	// goroutines are conditional, so each one is added when spawned
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
 
 	instancesChan := fetchInstacneDetail(instanceId, client)
 
@@ -107,7 +444,30 @@ func fetchInstanceDefinition(instanceId *string, tz *time.Timezone, client *aws.
 	instanceDefinition.SetInstanceSummary(newInstanceSummary(instance, tz))
 	instanceDefinition.SetInstanceDetail(newInstanceDetail(instance, tz))
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		health := &instanceHealth{}
+		statuses, err := fetchInstanceStatuses(client, []string{*instance.InstanceId})
+		if err != nil {
+			health.apiError = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+		} else {
+			health.status = newInstanceStatus(instance, statuses[*instance.InstanceId], tz, gotime.Now())
+		}
+		instanceDefinition.SetHealth(health)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		instanceTypes, err := fetchInstanceTypes(client, []string{*instance.InstanceType}, nil)
+		// vCPU & memory are informative, they are left empty on error
+		if err == nil && len(instanceTypes) != 0 {
+			instanceDefinition.SetInstanceType(newInstanceTypeSummary(instanceTypes[0]))
+		}
+	}()
+
 	if instance.BlockDeviceMappings != nil {
+		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			volumesSummary := fetchInstanceVolumeSummary(instance.BlockDeviceMappings, client)
@@ -115,6 +475,7 @@ func fetchInstanceDefinition(instanceId *string, tz *time.Timezone, client *aws.
 		}()
 	}
 	if instance.NetworkInterfaces != nil {
+		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			ruleSummary := fetchIngressEgressRuleSummary(instance.NetworkInterfaces, client)
@@ -183,3 +544,1548 @@ func fetchIngressEgressRuleSummary(enis []*ec2.InstanceNetworkInterface, client
 
 	return &instanceIngressEgressRuleSummary{ingressRules: ingressRules, egressRules: egressRules}
 }
+
+func (f securityGroupListFetcher) Fetch() interface{} {
+	securityGroups, err := fetchSecurityGroups(f.client, f.filter.requestFilters())
+	if err != nil {
+		return &securityGroupListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(securityGroups) == 0 {
+		return &securityGroupListOutput{err: aws.NewErrorInfo(NoSecurityGroupFound(), viewer.INFO, nil)}
+	}
+	summaries := []*securityGroupSummary{}
+	for _, sg := range securityGroups {
+		summaries = append(summaries, newSecurityGroupSummary(sg))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if *summaries[i].vpcId != *summaries[j].vpcId {
+			return *summaries[i].vpcId < *summaries[j].vpcId
+		}
+		return *summaries[i].name < *summaries[j].name
+	})
+	return &securityGroupListOutput{securityGroups: summaries}
+}
+
+func (f securityGroupDefinitionFetcher) Fetch() interface{} {
+	data, err := f.client.EC2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{&f.id}})
+	if err != nil {
+		return &securityGroupDefinition{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(data.SecurityGroups) == 0 {
+		return &securityGroupDefinition{err: aws.NewErrorInfo(SecurityGroupNotFound(f.id), viewer.WARN, nil)}
+	}
+	sg := data.SecurityGroups[0]
+	description := awssdk.StringValue(sg.Description)
+	definition := &securityGroupDefinition{
+		summary: newSecurityGroupSummary(sg),
+		ruleSummary: &instanceIngressEgressRuleSummary{
+			ingressRules: newSecurityIngressRules(*sg.GroupId, *sg.GroupName, description, sg.IpPermissions),
+			egressRules:  newSecurityEgressRules(*sg.GroupId, *sg.GroupName, description, sg.IpPermissionsEgress),
+		},
+	}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(3)
+	errs := make([]error, 3)
+	go func() {
+		defer wg.Done()
+		enis, err := fetchNetworkInterfaces(f.client, []*ec2.Filter{newFilter("group-id", f.id)})
+		errs[0] = err
+		for _, eni := range enis {
+			definition.networkInterfaces = append(definition.networkInterfaces, newSecurityGroupNetworkInterface(eni))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		instances, err := fetchInstances(f.client, []*ec2.Filter{newFilter("instance.group-id", f.id)})
+		errs[1] = err
+		for _, instance := range instances {
+			definition.instances = append(definition.instances, newSecurityGroupInstance(instance))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		// a group can be referenced in ingress or egress rules of other groups
+		referencingGroups := map[string]*ec2.SecurityGroup{}
+		for _, filterName := range []string{"ip-permission.group-id", "egress.ip-permission.group-id"} {
+			groups, err := fetchSecurityGroups(f.client, []*ec2.Filter{newFilter(filterName, f.id)})
+			if err != nil {
+				errs[2] = err
+				return
+			}
+			for _, group := range groups {
+				referencingGroups[*group.GroupId] = group
+			}
+		}
+		for _, group := range referencingGroups {
+			definition.referencedBy = append(definition.referencedBy, newSecurityGroupReferences(f.id, group)...)
+		}
+		sort.SliceStable(definition.referencedBy, func(i, j int) bool {
+			return *definition.referencedBy[i].sgId < *definition.referencedBy[j].sgId
+		})
+	}()
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			definition.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+			break
+		}
+	}
+	return definition
+}
+
+func fetchSecurityGroups(client *aws.Client, filters []*ec2.Filter) ([]*ec2.SecurityGroup, error) {
+	securityGroups := []*ec2.SecurityGroup{}
+	input := &ec2.DescribeSecurityGroupsInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeSecurityGroupsPages(input, func(page *ec2.DescribeSecurityGroupsOutput, lastPage bool) bool {
+		securityGroups = append(securityGroups, page.SecurityGroups...)
+		return true
+	})
+	return securityGroups, err
+}
+
+func fetchNetworkInterfaces(client *aws.Client, filters []*ec2.Filter) ([]*ec2.NetworkInterface, error) {
+	enis := []*ec2.NetworkInterface{}
+	input := &ec2.DescribeNetworkInterfacesInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeNetworkInterfacesPages(input, func(page *ec2.DescribeNetworkInterfacesOutput, lastPage bool) bool {
+		enis = append(enis, page.NetworkInterfaces...)
+		return true
+	})
+	return enis, err
+}
+
+func fetchInstances(client *aws.Client, filters []*ec2.Filter) ([]*ec2.Instance, error) {
+	instances := []*ec2.Instance{}
+	input := &ec2.DescribeInstancesInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			instances = append(instances, reservation.Instances...)
+		}
+		return true
+	})
+	return instances, err
+}
+
+func (f securityGroupAuditFetcher) Fetch() interface{} {
+	regions := f.regions
+	if f.allRegions {
+		data, err := f.client.EC2.DescribeRegions(&ec2.DescribeRegionsInput{})
+		if err != nil {
+			return &securityGroupAuditOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+		}
+		regions = []string{}
+		for _, region := range data.Regions {
+			regions = append(regions, *region.RegionName)
+		}
+	}
+	if len(regions) == 0 {
+		regions = []string{f.client.Region()}
+	}
+	sort.Strings(regions)
+
+	output := &securityGroupAuditOutput{regions: regions}
+	mu := new(sync.Mutex)
+	wg := new(sync.WaitGroup)
+	wg.Add(len(regions))
+	for _, region := range regions {
+		go func(region string) {
+			defer wg.Done()
+			scannedGroups, findings, err := auditRegionSecurityGroups(region, f.client.ForRegion(region), f.egress)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				output.regionErrs = append(output.regionErrs, aws.NewErrorInfo(fmt.Errorf("%s: %w", region, aws.AWSError(err)), viewer.ERROR, nil))
+				return
+			}
+			output.scannedGroups += scannedGroups
+			output.findings = append(output.findings, findings...)
+		}(region)
+	}
+	wg.Wait()
+	sortFindings(output.findings)
+	sort.Slice(output.regionErrs, func(i, j int) bool {
+		return output.regionErrs[i].Err.Error() < output.regionErrs[j].Err.Error()
+	})
+	if len(output.regionErrs) == 0 && len(output.findings) == 0 {
+		output.err = aws.NewErrorInfo(NoSecurityGroupFinding(), viewer.INFO, nil)
+	}
+	return output
+}
+
+func auditRegionSecurityGroups(region string, client *aws.Client, egress bool) (int, []*securityGroupFinding, error) {
+	securityGroups, err := fetchSecurityGroups(client, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	enis, err := fetchNetworkInterfaces(client, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	// group id -> instances, or network interfaces which aren't attached to an instance
+	attachedTo := map[string][]string{}
+	// instance with several interfaces in the same group is listed once
+	seen := map[string]bool{}
+	for _, eni := range enis {
+		resource := *eni.NetworkInterfaceId
+		if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
+			resource = *eni.Attachment.InstanceId
+		}
+		for _, group := range eni.Groups {
+			if key := *group.GroupId + "/" + resource; !seen[key] {
+				seen[key] = true
+				attachedTo[*group.GroupId] = append(attachedTo[*group.GroupId], resource)
+			}
+		}
+	}
+	findings := []*securityGroupFinding{}
+	for _, sg := range securityGroups {
+		sort.Strings(attachedTo[*sg.GroupId])
+		findings = append(findings, auditSecurityGroup(region, sg, attachedTo[*sg.GroupId], egress)...)
+	}
+	return len(securityGroups), findings, nil
+}
+
+func (f reachabilityFetcher) Fetch() interface{} {
+	source, err := fetchReachabilitySource(f.from, f.client)
+	if err != nil {
+		return &reachabilityOutput{from: f.from, to: f.to, err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	instance, err := fetchInstanceByIdOrName(f.to, f.client)
+	if err != nil {
+		return &reachabilityOutput{from: f.from, to: f.to, err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	destination, err := fetchInstanceReachabilityEndpoint(instance, f.client)
+	if err != nil {
+		return &reachabilityOutput{from: f.from, to: f.to, err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	return evaluateReachability(source, destination, f.protocol, f.port)
+}
+
+// fetchReachabilitySource resolve source as security group id, CIDR/IP or instance id/name
+func fetchReachabilitySource(from string, client *aws.Client) (*reachabilityEndpoint, error) {
+	if strings.HasPrefix(from, "sg-") {
+		securityGroups, err := fetchSecurityGroups(client, []*ec2.Filter{newFilter("group-id", from)})
+		if err != nil {
+			return nil, err
+		}
+		if len(securityGroups) == 0 {
+			return nil, SecurityGroupNotFound(from)
+		}
+		return &reachabilityEndpoint{name: from, securityGroups: securityGroups}, nil
+	}
+	if cidr, err := parseEndpointCidr(from); err == nil {
+		return &reachabilityEndpoint{name: cidr.String(), cidrs: []*net.IPNet{cidr}, external: true}, nil
+	}
+	instance, err := fetchInstanceByIdOrName(from, client)
+	if err != nil {
+		return nil, err
+	}
+	return fetchInstanceReachabilityEndpoint(instance, client)
+}
+
+func fetchInstanceReachabilityEndpoint(instance *ec2.Instance, client *aws.Client) (*reachabilityEndpoint, error) {
+	endpoint := &reachabilityEndpoint{
+		name:     *instance.InstanceId,
+		subnetId: awssdk.StringValue(instance.SubnetId),
+	}
+	groupIds := []string{}
+	primaryIp := awssdk.StringValue(instance.PrivateIpAddress)
+	if cidr, err := parseEndpointCidr(primaryIp); err == nil {
+		endpoint.cidrs = append(endpoint.cidrs, cidr)
+	}
+	for _, eni := range instance.NetworkInterfaces {
+		for _, privateIp := range eni.PrivateIpAddresses {
+			if *privateIp.PrivateIpAddress == primaryIp {
+				continue
+			}
+			if cidr, err := parseEndpointCidr(*privateIp.PrivateIpAddress); err == nil {
+				endpoint.cidrs = append(endpoint.cidrs, cidr)
+			}
+		}
+		for _, group := range eni.Groups {
+			groupIds = append(groupIds, *group.GroupId)
+		}
+	}
+	if len(groupIds) != 0 {
+		securityGroups, err := fetchSecurityGroups(client, []*ec2.Filter{newFilter("group-id", groupIds...)})
+		if err != nil {
+			return nil, err
+		}
+		endpoint.securityGroups = securityGroups
+	}
+	if len(endpoint.subnetId) != 0 {
+		data, err := client.EC2.DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{
+			Filters: []*ec2.Filter{newFilter("association.subnet-id", endpoint.subnetId)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(data.NetworkAcls) != 0 {
+			endpoint.networkAcl = data.NetworkAcls[0]
+		}
+	}
+	return endpoint, nil
+}
+
+// fetchInstanceByIdOrName return instance by id (i-xxxx) or by unique Name tag
+func fetchInstanceByIdOrName(idOrName string, client *aws.Client) (*ec2.Instance, error) {
+	filter := newFilter("tag:Name", idOrName)
+	if strings.HasPrefix(idOrName, "i-") {
+		filter = newFilter("instance-id", idOrName)
+	}
+	instances, err := fetchInstances(client, []*ec2.Filter{filter, activeInstanceStateFilter()})
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) == 0 {
+		return nil, InstanceNotFound(idOrName)
+	}
+	if len(instances) > 1 {
+		return nil, MultipleInstanceFound(idOrName, len(instances))
+	}
+	return instances[0], nil
+}
+
+func (f vpcListFetcher) Fetch() interface{} {
+	vpcs, err := fetchVpcs(f.client, nil)
+	if err != nil {
+		return &vpcListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(vpcs) == 0 {
+		return &vpcListOutput{err: aws.NewErrorInfo(NoVpcFound(), viewer.INFO, nil)}
+	}
+	subnets, err := fetchSubnets(f.client, nil)
+	if err != nil {
+		return &vpcListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	instances, err := fetchInstances(f.client, []*ec2.Filter{activeInstanceStateFilter()})
+	if err != nil {
+		return &vpcListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	summaries := []*vpcSummary{}
+	for _, vpc := range vpcs {
+		summary := newVpcSummary(vpc)
+		for _, subnet := range subnets {
+			if *subnet.VpcId == *vpc.VpcId {
+				summary.subnets++
+			}
+		}
+		for _, instance := range instances {
+			if awssdk.StringValue(instance.VpcId) == *vpc.VpcId {
+				summary.instances++
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return *summaries[i].id < *summaries[j].id
+	})
+	return &vpcListOutput{vpcs: summaries}
+}
+
+func (f vpcDefinitionFetcher) Fetch() interface{} {
+	vpcs, err := fetchVpcs(f.client, []*ec2.Filter{newFilter(vpc_id_key, f.id)})
+	if err != nil {
+		return &vpcDefinition{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(vpcs) == 0 {
+		return &vpcDefinition{err: aws.NewErrorInfo(VpcNotFound(f.id), viewer.WARN, nil)}
+	}
+	definition := &vpcDefinition{summary: newVpcSummary(vpcs[0])}
+	vpcFilter := []*ec2.Filter{newFilter(vpc_id_key, f.id)}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(5)
+	errs := make([]error, 5)
+	go func() {
+		defer wg.Done()
+		definition.subnets, errs[0] = fetchSubnetSummaries(f.client, vpcFilter)
+		definition.summary.subnets = len(definition.subnets)
+		for _, subnet := range definition.subnets {
+			definition.summary.instances += subnet.instances
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		routeTables, err := fetchRouteTables(f.client, vpcFilter)
+		errs[1] = err
+		for _, routeTable := range routeTables {
+			definition.routeTables = append(definition.routeTables, newVpcRouteTable(routeTable))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		gateways := []*vpcGateway{}
+		errs[2] = f.client.EC2.DescribeInternetGatewaysPages(&ec2.DescribeInternetGatewaysInput{
+			Filters: []*ec2.Filter{newFilter("attachment.vpc-id", f.id)},
+		}, func(page *ec2.DescribeInternetGatewaysOutput, lastPage bool) bool {
+			for _, igw := range page.InternetGateways {
+				gateways = append(gateways, newInternetGateway(igw))
+			}
+			return true
+		})
+		if errs[2] != nil {
+			return
+		}
+		errs[2] = f.client.EC2.DescribeNatGatewaysPages(&ec2.DescribeNatGatewaysInput{
+			Filter: vpcFilter,
+		}, func(page *ec2.DescribeNatGatewaysOutput, lastPage bool) bool {
+			for _, nat := range page.NatGateways {
+				gateways = append(gateways, newNatGateway(nat))
+			}
+			return true
+		})
+		definition.gateways = gateways
+	}()
+	go func() {
+		defer wg.Done()
+		errs[3] = f.client.EC2.DescribeVpcEndpointsPages(&ec2.DescribeVpcEndpointsInput{
+			Filters: vpcFilter,
+		}, func(page *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
+			for _, endpoint := range page.VpcEndpoints {
+				definition.endpoints = append(definition.endpoints, newVpcEndpoint(endpoint))
+			}
+			return true
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		// vpc can be requester or accepter of peering connection
+		for _, filterName := range []string{"requester-vpc-info.vpc-id", "accepter-vpc-info.vpc-id"} {
+			err := f.client.EC2.DescribeVpcPeeringConnectionsPages(&ec2.DescribeVpcPeeringConnectionsInput{
+				Filters: []*ec2.Filter{newFilter(filterName, f.id)},
+			}, func(page *ec2.DescribeVpcPeeringConnectionsOutput, lastPage bool) bool {
+				for _, peering := range page.VpcPeeringConnections {
+					definition.peeringConnections = append(definition.peeringConnections, newVpcPeeringConnection(peering))
+				}
+				return true
+			})
+			if err != nil {
+				errs[4] = err
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			definition.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+			break
+		}
+	}
+	return definition
+}
+
+func (f subnetListFetcher) Fetch() interface{} {
+	subnets, err := fetchSubnetSummaries(f.client, f.filter.requestFilters())
+	if err != nil {
+		return &subnetListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(subnets) == 0 {
+		return &subnetListOutput{err: aws.NewErrorInfo(NoSubnetFound(), viewer.INFO, nil)}
+	}
+	return &subnetListOutput{subnets: subnets}
+}
+
+// fetchSubnetSummaries return subnets with their route table & number of instances, sorted by vpc & az
+func fetchSubnetSummaries(client *aws.Client, filters []*ec2.Filter) ([]*subnetSummary, error) {
+	subnets, err := fetchSubnets(client, filters)
+	if err != nil || len(subnets) == 0 {
+		return nil, err
+	}
+	vpcIds := map[string]bool{}
+	subnetIds := []string{}
+	for _, subnet := range subnets {
+		vpcIds[*subnet.VpcId] = true
+		subnetIds = append(subnetIds, *subnet.SubnetId)
+	}
+	vpcIdValues := []string{}
+	for vpcId := range vpcIds {
+		vpcIdValues = append(vpcIdValues, vpcId)
+	}
+	routeTables, err := fetchRouteTables(client, []*ec2.Filter{newFilter(vpc_id_key, vpcIdValues...)})
+	if err != nil {
+		return nil, err
+	}
+	instances, err := fetchInstances(client, []*ec2.Filter{newFilter(subnet_id_key, subnetIds...), activeInstanceStateFilter()})
+	if err != nil {
+		return nil, err
+	}
+
+	// subnet without explicit association uses main route table of its vpc
+	mainRouteTables := map[string]string{}
+	subnetRouteTables := map[string]string{}
+	for _, routeTable := range routeTables {
+		for _, association := range routeTable.Associations {
+			if awssdk.BoolValue(association.Main) {
+				mainRouteTables[*routeTable.VpcId] = *routeTable.RouteTableId
+			}
+			if association.SubnetId != nil {
+				subnetRouteTables[*association.SubnetId] = *routeTable.RouteTableId
+			}
+		}
+	}
+	instancesBySubnet := map[string]int{}
+	for _, instance := range instances {
+		instancesBySubnet[awssdk.StringValue(instance.SubnetId)]++
+	}
+
+	summaries := []*subnetSummary{}
+	for _, subnet := range subnets {
+		summary := newSubnetSummary(subnet)
+		if routeTableId, ok := subnetRouteTables[*subnet.SubnetId]; ok {
+			summary.routeTableId = routeTableId
+		} else if routeTableId, ok := mainRouteTables[*subnet.VpcId]; ok {
+			summary.routeTableId = fmt.Sprintf("%s(main)", routeTableId)
+		}
+		summary.instances = instancesBySubnet[*subnet.SubnetId]
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if *summaries[i].vpcId != *summaries[j].vpcId {
+			return *summaries[i].vpcId < *summaries[j].vpcId
+		}
+		if *summaries[i].az != *summaries[j].az {
+			return *summaries[i].az < *summaries[j].az
+		}
+		return *summaries[i].cidr < *summaries[j].cidr
+	})
+	return summaries, nil
+}
+
+func fetchVpcs(client *aws.Client, filters []*ec2.Filter) ([]*ec2.Vpc, error) {
+	vpcs := []*ec2.Vpc{}
+	input := &ec2.DescribeVpcsInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeVpcsPages(input, func(page *ec2.DescribeVpcsOutput, lastPage bool) bool {
+		vpcs = append(vpcs, page.Vpcs...)
+		return true
+	})
+	return vpcs, err
+}
+
+func fetchSubnets(client *aws.Client, filters []*ec2.Filter) ([]*ec2.Subnet, error) {
+	subnets := []*ec2.Subnet{}
+	input := &ec2.DescribeSubnetsInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeSubnetsPages(input, func(page *ec2.DescribeSubnetsOutput, lastPage bool) bool {
+		subnets = append(subnets, page.Subnets...)
+		return true
+	})
+	return subnets, err
+}
+
+func fetchRouteTables(client *aws.Client, filters []*ec2.Filter) ([]*ec2.RouteTable, error) {
+	routeTables := []*ec2.RouteTable{}
+	input := &ec2.DescribeRouteTablesInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeRouteTablesPages(input, func(page *ec2.DescribeRouteTablesOutput, lastPage bool) bool {
+		routeTables = append(routeTables, page.RouteTables...)
+		return true
+	})
+	return routeTables, err
+}
+
+func (f volumeListFetcher) Fetch() interface{} {
+	volumes, err := fetchVolumes(f.client, f.filter.requestFilters())
+	if err != nil {
+		return &volumeListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &volumeListOutput{}
+	for _, volume := range volumes {
+		if !f.filter.applyCustomFilter(volume) {
+			continue
+		}
+		summary := newVolumeSummary(volume, f.tz)
+		output.volumes = append(output.volumes, summary)
+		output.totalSize += summary.size
+	}
+	if len(output.volumes) == 0 {
+		return &volumeListOutput{err: aws.NewErrorInfo(NoVolumeFound(), viewer.INFO, nil)}
+	}
+	sort.Slice(output.volumes, func(i, j int) bool {
+		return output.volumes[i].createTime.After(*output.volumes[j].createTime)
+	})
+	return output
+}
+
+func (f snapshotListFetcher) Fetch() interface{} {
+	snapshots, err := fetchSnapshots(f.client, f.filter.owners, f.filter.requestFilters())
+	if err != nil {
+		return &snapshotListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &snapshotListOutput{}
+	for _, snapshot := range snapshots {
+		if !f.filter.applyCustomFilter(snapshot) {
+			continue
+		}
+		summary := newSnapshotSummary(snapshot, f.tz, f.filter.now)
+		output.snapshots = append(output.snapshots, summary)
+		output.totalSize += summary.size
+	}
+	if len(output.snapshots) == 0 {
+		return &snapshotListOutput{err: aws.NewErrorInfo(NoSnapshotFound(), viewer.INFO, nil)}
+	}
+	sortSnapshots(output.snapshots)
+	return output
+}
+
+// Fetch create crash-consistent snapshots of all volumes attached to instance
+func (f snapshotCreateFetcher) Fetch() interface{} {
+	instance, err := fetchInstanceByIdOrName(f.instance, f.client)
+	if err != nil {
+		return &snapshotCreateOutput{instanceId: f.instance, err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	input := &ec2.CreateSnapshotsInput{
+		InstanceSpecification: &ec2.InstanceSpecification{
+			InstanceId:        instance.InstanceId,
+			ExcludeBootVolume: awssdk.Bool(f.excludeBootVolume),
+		},
+		// tags given by user take precedence over volume tags
+		CopyTagsFromSource: awssdk.String(ec2.CopyTagsFromSourceVolume),
+	}
+	if len(f.description) != 0 {
+		input.Description = awssdk.String(f.description)
+	}
+	if len(f.tags) != 0 {
+		tags := []*ec2.Tag{}
+		for key, value := range f.tags {
+			tags = append(tags, &ec2.Tag{Key: awssdk.String(key), Value: awssdk.String(value)})
+		}
+		input.TagSpecifications = []*ec2.TagSpecification{{ResourceType: awssdk.String(ec2.ResourceTypeSnapshot), Tags: tags}}
+	}
+	apiOutput, err := f.client.EC2.CreateSnapshots(input)
+	if err != nil {
+		return &snapshotCreateOutput{instanceId: *instance.InstanceId, err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &snapshotCreateOutput{instanceId: *instance.InstanceId}
+	for _, info := range apiOutput.Snapshots {
+		output.snapshots = append(output.snapshots, newSnapshotSummary(&ec2.Snapshot{
+			SnapshotId:  info.SnapshotId,
+			VolumeId:    info.VolumeId,
+			VolumeSize:  info.VolumeSize,
+			State:       info.State,
+			Progress:    info.Progress,
+			Encrypted:   info.Encrypted,
+			Description: info.Description,
+			StartTime:   info.StartTime,
+			Tags:        info.Tags,
+		}, f.tz, *info.StartTime))
+	}
+	return output
+}
+
+// Fetch find unattached volumes & snapshots whose source volume no longer exists, they are deleted by Apply
+func (f volumeCleanupFetcher) Fetch() interface{} {
+	output := &volumeCleanupOutput{olderThan: f.olderThanValue}
+	volumes, err := fetchVolumes(f.client, nil)
+	if err != nil {
+		return &volumeCleanupOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	snapshots, err := fetchSnapshots(f.client, []string{"self"}, nil)
+	if err != nil {
+		return &volumeCleanupOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	// snapshots backing an AMI can't be deleted until the image is deregistered
+	images, err := f.client.EC2.DescribeImages(&ec2.DescribeImagesInput{Owners: awssdk.StringSlice([]string{"self"})})
+	if err != nil {
+		return &volumeCleanupOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	imageSnapshots := map[string]bool{}
+	for _, image := range images.Images {
+		for _, mapping := range image.BlockDeviceMappings {
+			if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+				imageSnapshots[*mapping.Ebs.SnapshotId] = true
+			}
+		}
+	}
+
+	now := gotime.Now()
+	olderThan := func(t *gotime.Time) bool {
+		return f.olderThan.IsZero() || (t != nil && t.Before(f.olderThan))
+	}
+	existingVolumes := map[string]bool{}
+	for _, volume := range volumes {
+		existingVolumes[*volume.VolumeId] = true
+		if *volume.State == ec2.VolumeStateAvailable && olderThan(volume.CreateTime) {
+			summary := newVolumeSummary(volume, f.tz)
+			output.volumes = append(output.volumes, summary)
+			output.volumeSize += summary.size
+		}
+	}
+	for _, snapshot := range snapshots {
+		volumeId := awssdk.StringValue(snapshot.VolumeId)
+		// copied snapshots & snapshots created by CreateImage have placeholder volume id
+		if volumeId == placeholder_volume_id || existingVolumes[volumeId] || imageSnapshots[*snapshot.SnapshotId] {
+			continue
+		}
+		if isManagedSnapshot(snapshot) || !olderThan(snapshot.StartTime) {
+			continue
+		}
+		summary := newSnapshotSummary(snapshot, f.tz, now)
+		output.snapshots = append(output.snapshots, summary)
+		output.snapshotSize += summary.size
+	}
+	if len(output.volumes) == 0 && len(output.snapshots) == 0 {
+		return &volumeCleanupOutput{err: aws.NewErrorInfo(NoOrphanedResourceFound(), viewer.INFO, nil)}
+	}
+	sortSnapshots(output.snapshots)
+	return output
+}
+
+func (f volumeCleanupFetcher) Confirmation(plan interface{}) string {
+	output := plan.(*volumeCleanupOutput)
+	if !f.delete || output.err != nil {
+		return ""
+	}
+	return fmt.Sprintf("Delete %d volume(s) and %d snapshot(s)?", len(output.volumes), len(output.snapshots))
+}
+
+func (f volumeCleanupFetcher) Apply(plan interface{}, confirmed bool) interface{} {
+	output := plan.(*volumeCleanupOutput)
+	if !confirmed {
+		return &volumeCleanupOutput{err: aws.NewErrorInfo(CleanupNotConfirmed(), viewer.WARN, nil)}
+	}
+	deletions := []*resourceDeletion{}
+	for _, volume := range output.volumes {
+		_, err := f.client.EC2.DeleteVolume(&ec2.DeleteVolumeInput{VolumeId: volume.id})
+		deletions = append(deletions, newResourceDeletion(*volume.id, err))
+	}
+	for _, snapshot := range output.snapshots {
+		_, err := f.client.EC2.DeleteSnapshot(&ec2.DeleteSnapshotInput{SnapshotId: snapshot.id})
+		deletions = append(deletions, newResourceDeletion(*snapshot.id, err))
+	}
+	return &volumeCleanupOutput{deletions: deletions}
+}
+
+// isManagedSnapshot snapshots of AWS Backup & Data Lifecycle Manager are deleted by their retention policy
+func isManagedSnapshot(snapshot *ec2.Snapshot) bool {
+	for _, tag := range snapshot.Tags {
+		key := awssdk.StringValue(tag.Key)
+		if strings.HasPrefix(key, "aws:backup") || strings.HasPrefix(key, "aws:dlm") {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchVolumes(client *aws.Client, filters []*ec2.Filter) ([]*ec2.Volume, error) {
+	volumes := []*ec2.Volume{}
+	input := &ec2.DescribeVolumesInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeVolumesPages(input, func(page *ec2.DescribeVolumesOutput, lastPage bool) bool {
+		volumes = append(volumes, page.Volumes...)
+		return true
+	})
+	return volumes, err
+}
+
+func fetchSnapshots(client *aws.Client, owners []string, filters []*ec2.Filter) ([]*ec2.Snapshot, error) {
+	snapshots := []*ec2.Snapshot{}
+	input := &ec2.DescribeSnapshotsInput{OwnerIds: awssdk.StringSlice(owners)}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeSnapshotsPages(input, func(page *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+		snapshots = append(snapshots, page.Snapshots...)
+		return true
+	})
+	return snapshots, err
+}
+
+// sortSnapshots newest first
+func sortSnapshots(snapshots []*snapshotSummary) {
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].startTime.After(*snapshots[j].startTime)
+	})
+}
+
+func (f imageListFetcher) Fetch() interface{} {
+	images, err := fetchImages(f.client, f.filter)
+	if err != nil {
+		return &imageListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(images) == 0 {
+		return &imageListOutput{err: aws.NewErrorInfo(NoImageFound(), viewer.INFO, nil)}
+	}
+	output := &imageListOutput{}
+	for _, image := range images {
+		output.images = append(output.images, newImageSummary(image, f.tz, f.filter.now))
+	}
+	return output
+}
+
+func (f imageDefinitionFetcher) Fetch() interface{} {
+	apiOutput, err := f.client.EC2.DescribeImages(&ec2.DescribeImagesInput{ImageIds: awssdk.StringSlice([]string{f.id})})
+	if err != nil {
+		return &imageDefinition{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(apiOutput.Images) == 0 {
+		return &imageDefinition{err: aws.NewErrorInfo(ImageNotFound(f.id), viewer.WARN, nil)}
+	}
+	image := apiOutput.Images[0]
+	definition := &imageDefinition{
+		summary:         newImageSummary(image, f.tz, gotime.Now()),
+		description:     firstValue(image.Description),
+		deprecationTime: firstValue(image.DeprecationTime),
+		tags:            newTags(image.Tags),
+	}
+	for _, mapping := range image.BlockDeviceMappings {
+		definition.blockDevices = append(definition.blockDevices, newImageBlockDevice(mapping))
+	}
+	// lineage of image: snapshot -> volume it was taken from
+	if len(definition.summary.snapshots) != 0 {
+		snapshots, err := f.client.EC2.DescribeSnapshots(&ec2.DescribeSnapshotsInput{SnapshotIds: awssdk.StringSlice(definition.summary.snapshots)})
+		if err != nil {
+			definition.err = aws.NewErrorInfo(aws.AWSError(err), viewer.WARN, nil)
+		} else {
+			sourceVolumes := map[string]string{}
+			for _, snapshot := range snapshots.Snapshots {
+				sourceVolumes[*snapshot.SnapshotId] = firstValue(snapshot.VolumeId)
+			}
+			for _, device := range definition.blockDevices {
+				if volumeId, ok := sourceVolumes[device.snapshotId]; ok {
+					device.sourceVolumeId = volumeId
+				}
+			}
+		}
+	}
+	instances, err := fetchInstances(f.client, []*ec2.Filter{newFilter(image_id_key, f.id), activeInstanceStateFilter()})
+	if err != nil {
+		definition.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+		return definition
+	}
+	for _, instance := range instances {
+		definition.instances = append(definition.instances, newSecurityGroupInstance(instance))
+	}
+	return definition
+}
+
+// Fetch find images not used by any instance, they are deregistered by Apply
+func (f imageUnusedFetcher) Fetch() interface{} {
+	images, err := fetchImages(f.client, f.filter)
+	if err != nil {
+		return &imageUnusedOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	instances, err := fetchInstances(f.client, []*ec2.Filter{activeInstanceStateFilter()})
+	if err != nil {
+		return &imageUnusedOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	// images of launch templates & launch configurations are used by next launched instances
+	usedImages, err := fetchLaunchImages(f.client)
+	if err != nil {
+		return &imageUnusedOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	for _, instance := range instances {
+		usedImages[awssdk.StringValue(instance.ImageId)] = true
+	}
+	output := &imageUnusedOutput{olderThan: f.olderThan}
+	for _, image := range images {
+		if usedImages[*image.ImageId] {
+			continue
+		}
+		summary := newImageSummary(image, f.tz, f.filter.now)
+		output.images = append(output.images, summary)
+		output.totalSize += summary.size
+	}
+	if len(output.images) == 0 {
+		return &imageUnusedOutput{err: aws.NewErrorInfo(NoUnusedImageFound(), viewer.INFO, nil)}
+	}
+	return output
+}
+
+func (f imageUnusedFetcher) Confirmation(plan interface{}) string {
+	output := plan.(*imageUnusedOutput)
+	if !f.deregister || output.err != nil {
+		return ""
+	}
+	return fmt.Sprintf("Deregister %d image(s) and delete their snapshots?", len(output.images))
+}
+
+// Apply deregister images & delete backing snapshots
+func (f imageUnusedFetcher) Apply(plan interface{}, confirmed bool) interface{} {
+	output := plan.(*imageUnusedOutput)
+	if !confirmed {
+		return &imageUnusedOutput{err: aws.NewErrorInfo(DeregisterNotConfirmed(), viewer.WARN, nil)}
+	}
+	deletions := []*resourceDeletion{}
+	for _, image := range output.images {
+		_, err := f.client.EC2.DeregisterImage(&ec2.DeregisterImageInput{ImageId: image.id})
+		deletions = append(deletions, newResourceDeletion(*image.id, err))
+		// snapshots are still in use if deregister failed
+		if err != nil {
+			continue
+		}
+		for _, snapshotId := range image.snapshots {
+			_, err := f.client.EC2.DeleteSnapshot(&ec2.DeleteSnapshotInput{SnapshotId: awssdk.String(snapshotId)})
+			deletions = append(deletions, newResourceDeletion(snapshotId, err))
+		}
+	}
+	return &imageUnusedOutput{deletions: deletions}
+}
+
+// fetchLaunchImages return image ids of $Default & $Latest launch template versions, of versions pinned by auto scaling groups
+// and of launch configurations
+func fetchLaunchImages(client *aws.Client) (map[string]bool, error) {
+	images := map[string]bool{}
+	addImages := func(page *ec2.DescribeLaunchTemplateVersionsOutput, lastPage bool) bool {
+		for _, version := range page.LaunchTemplateVersions {
+			if version.LaunchTemplateData != nil && version.LaunchTemplateData.ImageId != nil {
+				images[*version.LaunchTemplateData.ImageId] = true
+			}
+		}
+		return true
+	}
+	input := &ec2.DescribeLaunchTemplateVersionsInput{Versions: awssdk.StringSlice([]string{"$Default", "$Latest"})}
+	if err := client.EC2.DescribeLaunchTemplateVersionsPages(input, addImages); err != nil {
+		return nil, err
+	}
+	pinnedVersions, err := fetchPinnedLaunchTemplateVersions(client)
+	if err != nil {
+		return nil, err
+	}
+	for template, versions := range pinnedVersions {
+		input := &ec2.DescribeLaunchTemplateVersionsInput{Versions: awssdk.StringSlice(versions)}
+		if len(template.id) != 0 {
+			input.LaunchTemplateId = awssdk.String(template.id)
+		} else {
+			input.LaunchTemplateName = awssdk.String(template.name)
+		}
+		if err := client.EC2.DescribeLaunchTemplateVersionsPages(input, addImages); err != nil {
+			return nil, err
+		}
+	}
+	err = client.AutoScaling.DescribeLaunchConfigurationsPages(&autoscaling.DescribeLaunchConfigurationsInput{}, func(page *autoscaling.DescribeLaunchConfigurationsOutput, lastPage bool) bool {
+		for _, config := range page.LaunchConfigurations {
+			images[awssdk.StringValue(config.ImageId)] = true
+		}
+		return true
+	})
+	return images, err
+}
+
+// launchTemplateRef is id or name of launch template referenced by auto scaling group
+type launchTemplateRef struct {
+	id   string
+	name string
+}
+
+// fetchPinnedLaunchTemplateVersions return numbered versions referenced by auto scaling groups directly or by
+// mixed instances policy, $Default & $Latest are already resolved
+func fetchPinnedLaunchTemplateVersions(client *aws.Client) (map[launchTemplateRef][]string, error) {
+	versions := map[launchTemplateRef][]string{}
+	seen := map[launchTemplateRef]map[string]bool{}
+	add := func(spec *autoscaling.LaunchTemplateSpecification) {
+		if spec == nil {
+			return
+		}
+		version := awssdk.StringValue(spec.Version)
+		if len(version) == 0 || version == "$Default" || version == "$Latest" {
+			return
+		}
+		template := launchTemplateRef{id: awssdk.StringValue(spec.LaunchTemplateId), name: awssdk.StringValue(spec.LaunchTemplateName)}
+		if seen[template] == nil {
+			seen[template] = map[string]bool{}
+		}
+		if !seen[template][version] {
+			seen[template][version] = true
+			versions[template] = append(versions[template], version)
+		}
+	}
+	err := client.AutoScaling.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{}, func(page *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) bool {
+		for _, group := range page.AutoScalingGroups {
+			add(group.LaunchTemplate)
+			if group.MixedInstancesPolicy == nil || group.MixedInstancesPolicy.LaunchTemplate == nil {
+				continue
+			}
+			add(group.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification)
+			for _, override := range group.MixedInstancesPolicy.LaunchTemplate.Overrides {
+				add(override.LaunchTemplateSpecification)
+			}
+		}
+		return true
+	})
+	return versions, err
+}
+
+// fetchImages return images matching filter, newest first
+func fetchImages(client *aws.Client, filter *ImageListFilter) ([]*ec2.Image, error) {
+	input := &ec2.DescribeImagesInput{Owners: awssdk.StringSlice(filter.owners)}
+	if filters := filter.requestFilters(); len(filters) != 0 {
+		input.Filters = filters
+	}
+	apiOutput, err := client.EC2.DescribeImages(input)
+	if err != nil {
+		return nil, err
+	}
+	images := []*ec2.Image{}
+	for _, image := range apiOutput.Images {
+		if filter.applyCustomFilter(image) {
+			images = append(images, image)
+		}
+	}
+	// CreationDate is in ISO 8601 format, lexical order is chronological order
+	sort.Slice(images, func(i, j int) bool {
+		return awssdk.StringValue(images[i].CreationDate) > awssdk.StringValue(images[j].CreationDate)
+	})
+	return images, nil
+}
+
+func (f elasticIpListFetcher) Fetch() interface{} {
+	apiOutput, err := f.client.EC2.DescribeAddresses(&ec2.DescribeAddressesInput{})
+	if err != nil {
+		return &elasticIpListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &elasticIpListOutput{}
+	for _, address := range apiOutput.Addresses {
+		summary := newElasticIpSummary(address)
+		if summary.isAssociated() && f.unassociated {
+			continue
+		}
+		if !summary.isAssociated() {
+			output.unassociated++
+		}
+		output.addresses = append(output.addresses, summary)
+	}
+	if len(output.addresses) == 0 {
+		return &elasticIpListOutput{err: aws.NewErrorInfo(NoElasticIpFound(), viewer.INFO, nil)}
+	}
+	sort.Slice(output.addresses, func(i, j int) bool {
+		return *output.addresses[i].publicIp < *output.addresses[j].publicIp
+	})
+	return output
+}
+
+func (f networkInterfaceListFetcher) Fetch() interface{} {
+	enis, err := fetchNetworkInterfaces(f.client, f.filter.requestFilters())
+	if err != nil {
+		return &networkInterfaceListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(enis) == 0 {
+		return &networkInterfaceListOutput{err: aws.NewErrorInfo(NoNetworkInterfaceFound(), viewer.INFO, nil)}
+	}
+	output := &networkInterfaceListOutput{}
+	for _, eni := range enis {
+		summary := newNetworkInterfaceSummary(eni)
+		if *summary.status == ec2.NetworkInterfaceStatusAvailable {
+			output.available++
+		}
+		output.networkInterfaces = append(output.networkInterfaces, summary)
+	}
+	sort.Slice(output.networkInterfaces, func(i, j int) bool {
+		if output.networkInterfaces[i].vpcId != output.networkInterfaces[j].vpcId {
+			return output.networkInterfaces[i].vpcId < output.networkInterfaces[j].vpcId
+		}
+		return *output.networkInterfaces[i].id < *output.networkInterfaces[j].id
+	})
+	return output
+}
+
+// Fetch start ssm session or ssh on instance, it returns once session is closed
+func (f connectFetcher) Fetch() interface{} {
+	instance, err := fetchInstanceByIdOrName(f.target, f.client)
+	if err != nil {
+		return &connectOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &connectOutput{instanceId: *instance.InstanceId, name: nameTag(instance.Tags), method: f.method, forward: NO_VALUE}
+	if f.forward != nil {
+		output.forward = f.forward.String()
+	}
+	if *instance.State.Name != ec2.InstanceStateNameRunning {
+		output.err = aws.NewErrorInfo(InstanceNotRunning(*instance.InstanceId, *instance.State.Name), viewer.WARN, nil)
+		return output
+	}
+
+	if output.method == CONNECT_METHOD_AUTO {
+		output.method = CONNECT_METHOD_SSH
+		if err := checkSSMConnectable(instance, f.client); err == nil {
+			output.method = CONNECT_METHOD_SSM
+		}
+	}
+	if output.method == CONNECT_METHOD_SSM {
+		err = f.startSSMSession(instance, output)
+	} else {
+		err = f.startSSH(instance, output)
+	}
+	if err != nil {
+		output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+	}
+	return output
+}
+
+func (f connectFetcher) startSSMSession(instance *ec2.Instance, output *connectOutput) error {
+	if err := checkSSMConnectable(instance, f.client); err != nil {
+		return err
+	}
+	input := &ssm.StartSessionInput{Target: instance.InstanceId}
+	if document, parameters := ssmDocument(f.forward); len(document) != 0 {
+		input.DocumentName = awssdk.String(document)
+		input.Parameters = parameters
+	}
+	session, err := f.client.SSM.StartSession(input)
+	if err != nil {
+		return aws.AWSError(err)
+	}
+	output.target = *session.SessionId
+
+	// session-manager-plugin protocol: response, region, operation, profile, request, endpoint
+	sessionJson, _ := json.Marshal(session)
+	inputJson, _ := json.Marshal(input)
+	cmd := exec.Command(SESSION_MANAGER_PLUGIN, string(sessionJson), f.client.Region(), "StartSession", f.client.Profile(), string(inputJson), f.client.SSM.Endpoint)
+	fmt.Printf("Starting session %s on %s via %s\n", *session.SessionId, *instance.InstanceId, CONNECT_METHOD_SSM)
+	if err := runInteractive(cmd); err != nil {
+		f.client.SSM.TerminateSession(&ssm.TerminateSessionInput{SessionId: session.SessionId})
+		return err
+	}
+	return nil
+}
+
+func (f connectFetcher) startSSH(instance *ec2.Instance, output *connectOutput) error {
+	user := f.user
+	if len(user) == 0 {
+		imageName := ""
+		images, err := f.client.EC2.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{instance.ImageId}})
+		// image can be deregistered or not shared anymore, default user is used then
+		if err == nil && len(images.Images) != 0 {
+			imageName = awssdk.StringValue(images.Images[0].Name)
+		}
+		if user, err = sshUser(instance, imageName); err != nil {
+			return err
+		}
+	}
+	keyFile := f.keyFile
+	if len(keyFile) == 0 {
+		keyFile = sshKeyFile(instance)
+	}
+	address, err := sshAddress(instance, f.ipType)
+	if err != nil {
+		return err
+	}
+	output.target = fmt.Sprintf("%s@%s", user, address)
+
+	args := sshArgs(user, address, keyFile, f.forward)
+	fmt.Printf("Connecting to %s via ssh %s\n", *instance.InstanceId, strings.Join(args, " "))
+	return runInteractive(exec.Command("ssh", args...))
+}
+
+// checkSSMConnectable session needs session-manager-plugin locally and instance online in ssm
+func checkSSMConnectable(instance *ec2.Instance, client *aws.Client) error {
+	if _, err := exec.LookPath(SESSION_MANAGER_PLUGIN); err != nil {
+		return SessionManagerPluginNotFound()
+	}
+	apiOutput, err := client.SSM.DescribeInstanceInformation(&ssm.DescribeInstanceInformationInput{
+		Filters: []*ssm.InstanceInformationStringFilter{{Key: awssdk.String("InstanceIds"), Values: []*string{instance.InstanceId}}},
+	})
+	if err != nil {
+		return aws.AWSError(err)
+	}
+	for _, info := range apiOutput.InstanceInformationList {
+		if awssdk.StringValue(info.PingStatus) == ssm.PingStatusOnline {
+			return nil
+		}
+	}
+	return InstanceNotManagedBySSM(*instance.InstanceId)
+}
+
+// runInteractive attach terminal to cmd, interrupt is handled by cmd so port forwarding can be stopped with ctrl+c
+func runInteractive(cmd *exec.Cmd) error {
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	err := cmd.Run()
+	// non zero exit of remote shell isn't an error of connect
+	if _, ok := err.(*exec.ExitError); ok {
+		return nil
+	}
+	return err
+}
+
+func (f consoleOutputFetcher) Fetch() interface{} {
+	instance, err := fetchInstanceByIdOrName(f.target, f.client)
+	if err != nil {
+		return &consoleOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &consoleOutput{instanceId: *instance.InstanceId}
+	apiOutput, err := f.client.EC2.GetConsoleOutput(&ec2.GetConsoleOutputInput{
+		InstanceId: instance.InstanceId,
+		Latest:     awssdk.Bool(f.latest),
+	})
+	if err != nil {
+		output.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+		return output
+	}
+	output.timestamp = f.tz.AdaptTimezone(apiOutput.Timestamp)
+	content, err := base64.StdEncoding.DecodeString(awssdk.StringValue(apiOutput.Output))
+	if err != nil {
+		output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+		return output
+	}
+	output.content = tailLines(string(content), f.tail)
+
+	if len(f.screenshotFile) != 0 {
+		screenshot, err := f.client.EC2.GetConsoleScreenshot(&ec2.GetConsoleScreenshotInput{
+			InstanceId: instance.InstanceId,
+			WakeUp:     awssdk.Bool(true),
+		})
+		if err != nil {
+			output.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+			return output
+		}
+		image, err := base64.StdEncoding.DecodeString(awssdk.StringValue(screenshot.ImageData))
+		if err == nil {
+			err = os.WriteFile(f.screenshotFile, image, 0644)
+		}
+		if err != nil {
+			output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+			return output
+		}
+		output.screenshotFile = f.screenshotFile
+	}
+	if len(strings.TrimSpace(output.content)) == 0 && len(output.screenshotFile) == 0 {
+		output.err = aws.NewErrorInfo(NoConsoleOutput(*instance.InstanceId), viewer.INFO, nil)
+	}
+	return output
+}
+
+func (f userDataFetcher) Fetch() interface{} {
+	instance, err := fetchInstanceByIdOrName(f.target, f.client)
+	if err != nil {
+		return &userDataOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	attribute, err := f.client.EC2.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{
+		InstanceId: instance.InstanceId,
+		Attribute:  awssdk.String(ec2.InstanceAttributeNameUserData),
+	})
+	if err != nil {
+		return &userDataOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if attribute.UserData == nil || len(awssdk.StringValue(attribute.UserData.Value)) == 0 {
+		return &userDataOutput{err: aws.NewErrorInfo(NoUserData(*instance.InstanceId), viewer.INFO, nil)}
+	}
+	parts, err := decodeUserData(*attribute.UserData.Value)
+	if err != nil {
+		return &userDataOutput{err: aws.NewErrorInfo(err, viewer.ERROR, nil)}
+	}
+	return &userDataOutput{instanceId: *instance.InstanceId, parts: parts}
+}
+
+// tailLines return last n lines of text, all lines if n isn't positive
+func tailLines(text string, n int) string {
+	if n <= 0 {
+		return text
+	}
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) <= n {
+		return text
+	}
+	return strings.Join(lines[len(lines)-n:], "\n")
+}
+
+func (f instanceTypeListFetcher) Fetch() interface{} {
+	instanceTypes, err := fetchInstanceTypes(f.client, nil, f.filter.requestFilters())
+	if err != nil {
+		return &instanceTypeListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &instanceTypeListOutput{}
+	for _, info := range instanceTypes {
+		summary := newInstanceTypeSummary(info)
+		if f.filter.applyCustomFilter(summary) {
+			output.instanceTypes = append(output.instanceTypes, summary)
+		}
+	}
+	if len(output.instanceTypes) == 0 {
+		return &instanceTypeListOutput{err: aws.NewErrorInfo(NoInstanceTypeFound(), viewer.INFO, nil)}
+	}
+	sort.Slice(output.instanceTypes, func(i, j int) bool {
+		a, b := output.instanceTypes[i], output.instanceTypes[j]
+		if a.vcpus != b.vcpus {
+			return a.vcpus < b.vcpus
+		}
+		if a.memory != b.memory {
+			return a.memory < b.memory
+		}
+		return a.typee < b.typee
+	})
+	return output
+}
+
+func (f instanceTypeCompareFetcher) Fetch() interface{} {
+	instanceTypes, err := fetchInstanceTypes(f.client, f.instanceTypes, nil)
+	if err != nil {
+		return &instanceTypeCompareOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	summaries := map[string]*instanceTypeSummary{}
+	for _, info := range instanceTypes {
+		summaries[*info.InstanceType] = newInstanceTypeSummary(info)
+	}
+	// keep order given by user
+	output := &instanceTypeCompareOutput{}
+	for _, instanceType := range f.instanceTypes {
+		summary, ok := summaries[instanceType]
+		if !ok {
+			return &instanceTypeCompareOutput{err: aws.NewErrorInfo(InstanceTypeNotFound(instanceType), viewer.WARN, nil)}
+		}
+		output.instanceTypes = append(output.instanceTypes, summary)
+	}
+	return output
+}
+
+func fetchInstanceTypes(client *aws.Client, instanceTypes []string, filters []*ec2.Filter) ([]*ec2.InstanceTypeInfo, error) {
+	infos := []*ec2.InstanceTypeInfo{}
+	input := &ec2.DescribeInstanceTypesInput{}
+	if len(instanceTypes) != 0 {
+		input.InstanceTypes = awssdk.StringSlice(instanceTypes)
+	}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := client.EC2.DescribeInstanceTypesPages(input, func(page *ec2.DescribeInstanceTypesOutput, lastPage bool) bool {
+		infos = append(infos, page.InstanceTypes...)
+		return true
+	})
+	return infos, err
+}
+
+func (f launchTemplateListFetcher) Fetch() interface{} {
+	templates, err := fetchLaunchTemplates(f.client, f.filter.requestFilters())
+	if err != nil {
+		return &launchTemplateListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(templates) == 0 {
+		return &launchTemplateListOutput{err: aws.NewErrorInfo(NoLaunchTemplateFound(), viewer.INFO, nil)}
+	}
+	output := &launchTemplateListOutput{}
+	for _, template := range templates {
+		output.launchTemplates = append(output.launchTemplates, newLaunchTemplateSummary(template, f.tz))
+	}
+	sort.Slice(output.launchTemplates, func(i, j int) bool {
+		return output.launchTemplates[i].name < output.launchTemplates[j].name
+	})
+	return output
+}
+
+func (f launchTemplateDefinitionFetcher) Fetch() interface{} {
+	templates, err := fetchLaunchTemplates(f.client, nil, f.target)
+	if err != nil {
+		return &launchTemplateDefinition{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(templates) == 0 {
+		return &launchTemplateDefinition{err: aws.NewErrorInfo(LaunchTemplateNotFound(f.target), viewer.WARN, nil)}
+	}
+	version, err := fetchLaunchTemplateVersion(f.client, f.target, f.version)
+	if err != nil {
+		return &launchTemplateDefinition{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	definition := newLaunchTemplateDefinition(version, f.tz)
+	definition.summary = newLaunchTemplateSummary(templates[0], f.tz)
+	data := version.LaunchTemplateData
+	if data == nil {
+		return definition
+	}
+	parts, _, err := launchTemplateUserData(data)
+	if err != nil {
+		definition.err = aws.NewErrorInfo(err, viewer.WARN, nil)
+		return definition
+	}
+	definition.userData = parts
+
+	// template can refer security groups by id or, in default vpc, by name
+	filters := [][]*ec2.Filter{}
+	if len(definition.securityGroups) != 0 {
+		groupIds := []string{}
+		for id := range definition.securityGroups {
+			groupIds = append(groupIds, id)
+		}
+		filters = append(filters, []*ec2.Filter{newFilter("group-id", groupIds...)})
+	}
+	if len(data.SecurityGroups) != 0 {
+		// names are unique only within a vpc
+		vpcId, err := launchTemplateVpcId(f.client, data)
+		if err != nil {
+			definition.err = aws.NewErrorInfo(aws.AWSError(err), viewer.WARN, nil)
+			return definition
+		}
+		if len(vpcId) != 0 {
+			filters = append(filters, []*ec2.Filter{
+				newFilter(group_name_key, awssdk.StringValueSlice(data.SecurityGroups)...),
+				newFilter(vpc_id_key, vpcId),
+			})
+		}
+	}
+	// filters are ANDed, so ids & names are separate requests
+	for _, filter := range filters {
+		securityGroups, err := fetchSecurityGroups(f.client, filter)
+		if err != nil {
+			definition.err = aws.NewErrorInfo(aws.AWSError(err), viewer.WARN, nil)
+			return definition
+		}
+		for _, sg := range securityGroups {
+			definition.securityGroups[*sg.GroupId] = *sg.GroupName
+		}
+	}
+	return definition
+}
+
+// launchTemplateVpcId return vpc of template subnet, or default vpc when template has no subnet,
+// empty when there is no default vpc
+func launchTemplateVpcId(client *aws.Client, data *ec2.ResponseLaunchTemplateData) (string, error) {
+	for _, eni := range data.NetworkInterfaces {
+		if eni.SubnetId == nil {
+			continue
+		}
+		subnets, err := fetchSubnets(client, []*ec2.Filter{newFilter(subnet_id_key, *eni.SubnetId)})
+		if err != nil || len(subnets) == 0 {
+			return "", err
+		}
+		return awssdk.StringValue(subnets[0].VpcId), nil
+	}
+	vpcs, err := fetchVpcs(client, []*ec2.Filter{newFilter("is-default", "true")})
+	if err != nil || len(vpcs) == 0 {
+		return "", err
+	}
+	return awssdk.StringValue(vpcs[0].VpcId), nil
+}
+
+func (f launchTemplateDiffFetcher) Fetch() interface{} {
+	from, err := fetchLaunchTemplateVersion(f.client, f.target, f.from)
+	if err != nil {
+		return &launchTemplateDiffOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	to, err := fetchLaunchTemplateVersion(f.client, f.target, f.to)
+	if err != nil {
+		return &launchTemplateDiffOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	output := &launchTemplateDiffOutput{
+		id:      *from.LaunchTemplateId,
+		from:    *from.VersionNumber,
+		to:      *to.VersionNumber,
+		changes: diffLaunchTemplateFields(flattenLaunchTemplateData(from.LaunchTemplateData), flattenLaunchTemplateData(to.LaunchTemplateData)),
+	}
+	if _, output.fromUserData, err = launchTemplateUserData(from.LaunchTemplateData); err != nil {
+		output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+		return output
+	}
+	if _, output.toUserData, err = launchTemplateUserData(to.LaunchTemplateData); err != nil {
+		output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+		return output
+	}
+	if len(output.changes) == 0 && output.fromUserData == output.toUserData {
+		output.err = aws.NewErrorInfo(NoLaunchTemplateChange(output.id, output.from, output.to), viewer.INFO, nil)
+	}
+	return output
+}
+
+// fetchLaunchTemplates return templates matching filters, or the template given by id or name
+func fetchLaunchTemplates(client *aws.Client, filters []*ec2.Filter, target ...string) ([]*ec2.LaunchTemplate, error) {
+	templates := []*ec2.LaunchTemplate{}
+	input := &ec2.DescribeLaunchTemplatesInput{}
+	if len(filters) != 0 {
+		input.Filters = filters
+	}
+	for _, value := range target {
+		if isLaunchTemplateId(value) {
+			input.LaunchTemplateIds = append(input.LaunchTemplateIds, awssdk.String(value))
+		} else {
+			input.LaunchTemplateNames = append(input.LaunchTemplateNames, awssdk.String(value))
+		}
+	}
+	err := client.EC2.DescribeLaunchTemplatesPages(input, func(page *ec2.DescribeLaunchTemplatesOutput, lastPage bool) bool {
+		templates = append(templates, page.LaunchTemplates...)
+		return true
+	})
+	return templates, err
+}
+
+// fetchLaunchTemplateVersion version can be a number, $Latest or $Default
+func fetchLaunchTemplateVersion(client *aws.Client, target, version string) (*ec2.LaunchTemplateVersion, error) {
+	input := &ec2.DescribeLaunchTemplateVersionsInput{Versions: []*string{awssdk.String(version)}}
+	if isLaunchTemplateId(target) {
+		input.LaunchTemplateId = awssdk.String(target)
+	} else {
+		input.LaunchTemplateName = awssdk.String(target)
+	}
+	apiOutput, err := client.EC2.DescribeLaunchTemplateVersions(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(apiOutput.LaunchTemplateVersions) == 0 {
+		return nil, LaunchTemplateVersionNotFound(target, version)
+	}
+	return apiOutput.LaunchTemplateVersions[0], nil
+}
+
+func (f spotRequestListFetcher) Fetch() interface{} {
+	requests := []*ec2.SpotInstanceRequest{}
+	input := &ec2.DescribeSpotInstanceRequestsInput{}
+	if filters := f.filter.requestFilters(); len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := f.client.EC2.DescribeSpotInstanceRequestsPages(input, func(page *ec2.DescribeSpotInstanceRequestsOutput, lastPage bool) bool {
+		requests = append(requests, page.SpotInstanceRequests...)
+		return true
+	})
+	if err != nil {
+		return &spotRequestListOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(requests) == 0 {
+		return &spotRequestListOutput{err: aws.NewErrorInfo(NoSpotRequestFound(), viewer.INFO, nil)}
+	}
+	output := &spotRequestListOutput{}
+	for _, request := range requests {
+		output.spotRequests = append(output.spotRequests, newSpotRequestSummary(request, f.tz))
+	}
+	// newest request first
+	sort.SliceStable(output.spotRequests, func(i, j int) bool {
+		return output.spotRequests[i].createdTime.After(*output.spotRequests[j].createdTime)
+	})
+	return output
+}
+
+func (f spotPriceHistoryFetcher) Fetch() interface{} {
+	start, end := f.filter.startTime(), f.filter.endTime()
+	prices := []*ec2.SpotPrice{}
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		StartTime:           awssdk.Time(start),
+		EndTime:             awssdk.Time(end),
+		ProductDescriptions: awssdk.StringSlice(f.filter.products),
+	}
+	if len(f.filter.instanceTypes) != 0 {
+		input.InstanceTypes = awssdk.StringSlice(f.filter.instanceTypes)
+	}
+	if filters := f.filter.requestFilters(); len(filters) != 0 {
+		input.Filters = filters
+	}
+	err := f.client.EC2.DescribeSpotPriceHistoryPages(input, func(page *ec2.DescribeSpotPriceHistoryOutput, lastPage bool) bool {
+		prices = append(prices, page.SpotPriceHistory...)
+		return true
+	})
+	if err != nil {
+		return &spotPriceHistoryOutput{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	if len(prices) == 0 {
+		return &spotPriceHistoryOutput{err: aws.NewErrorInfo(NoSpotPriceFound(), viewer.INFO, nil)}
+	}
+	return &spotPriceHistoryOutput{
+		start:  *f.tz.AdaptTimezone(&start),
+		end:    *f.tz.AdaptTimezone(&end),
+		prices: newSpotPriceSummaries(prices, start, end),
+	}
+}
@@ -1,36 +1,304 @@
 package ec2
 
 import (
+	"cloudctl/expr"
+	ctltime "cloudctl/time"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
 const (
-	instance_state_name_key = "instance-state-name"
-	instance_type_key       = "instance-type"
-	az_key                  = "availability-zone"
-	vpc_id_key              = "vpc-id"
-	subnet_id_key           = "subnet-id"
-	launch_time_key         = "launch-time"
+	instance_state_name_key  = "instance-state-name"
+	instance_type_key        = "instance-type"
+	az_key                   = "availability-zone"
+	vpc_id_key               = "vpc-id"
+	subnet_id_key            = "subnet-id"
+	launch_time_key          = "launch-time"
+	group_name_key           = "group-name"
+	tag_key_prefix           = "tag:"
+	tag_key_key              = "tag-key"
+	status_key               = "status"
+	encrypted_key            = "encrypted"
+	volume_type_key          = "volume-type"
+	volume_id_key            = "volume-id"
+	image_id_key             = "image-id"
+	name_key                 = "name"
+	architecture_key         = "architecture"
+	interface_type_key       = "interface-type"
+	supported_arch_key       = "processor-info.supported-architecture"
+	current_generation_key   = "current-generation"
+	ebs_optimized_key        = "ebs-info.ebs-optimized-support"
+	asg_name_tag_key         = "aws:autoscaling:groupName"
+	launch_template_name_key = "launch-template-name"
+	instance_lifecycle_key   = "instance-lifecycle"
+	state_key                = "state"
+	placeholder_volume_id    = "vol-ffffffff"
 )
 
 type InstanceListFilterOptFunc func(*InstanceListFilter)
 
+type SecurityGroupListFilterOptFunc func(*SecurityGroupListFilter)
+
+type SubnetListFilterOptFunc func(*SubnetListFilter)
+
+type VolumeListFilterOptFunc func(*VolumeListFilter)
+
+type SnapshotListFilterOptFunc func(*SnapshotListFilter)
+
+type ImageListFilterOptFunc func(*ImageListFilter)
+
+type NetworkInterfaceListFilterOptFunc func(*NetworkInterfaceListFilter)
+
+type InstanceTypeListFilterOptFunc func(*InstanceTypeListFilter)
+
+type LaunchTemplateListFilterOptFunc func(*LaunchTemplateListFilter)
+
+type SpotRequestListFilterOptFunc func(*SpotRequestListFilter)
+
+type SpotPriceHistoryFilterOptFunc func(*SpotPriceHistoryFilter)
+
 type InstanceListFilter struct {
 	instanceStates []string
 	instanceTypes  []string
 	azs            []string
 	vpcIds         []string
 	subnetIds      []string
+	asgNames       []string
+	lifecycles     []string
 	hasPublicIp    *bool
 	launchAt       *string
+	launchRange    *ctltime.Range
+	impaired       bool
+	where          *expr.Expression
+}
+
+type SecurityGroupListFilter struct {
+	vpcIds     []string
+	groupNames []string
+	tags       map[string]string
+}
+
+func (f *SecurityGroupListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.vpcIds) != 0 {
+		filters = append(filters, &ec2.Filter{Name: aws.String(vpc_id_key), Values: aws.StringSlice(f.vpcIds)})
+	}
+	if len(f.groupNames) != 0 {
+		filters = append(filters, &ec2.Filter{Name: aws.String(group_name_key), Values: aws.StringSlice(f.groupNames)})
+	}
+	filters = append(filters, tagFilters(f.tags)...)
+	return filters
+}
+
+type SubnetListFilter struct {
+	vpcIds []string
+	azs    []string
+}
+
+func (f *SubnetListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.vpcIds) != 0 {
+		filters = append(filters, newFilter(vpc_id_key, f.vpcIds...))
+	}
+	if len(f.azs) != 0 {
+		filters = append(filters, newFilter(az_key, f.azs...))
+	}
+	return filters
+}
+
+type VolumeListFilter struct {
+	unattached bool
+	encrypted  *bool
+	types      []string
+	azs        []string
+	// size in GiB, 0 means no limit
+	minSize int64
+	maxSize int64
+	where   *expr.Expression
+}
+
+func (f *VolumeListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if f.unattached {
+		filters = append(filters, newFilter(status_key, ec2.VolumeStateAvailable))
+	}
+	if f.encrypted != nil {
+		filters = append(filters, newFilter(encrypted_key, fmt.Sprintf("%t", *f.encrypted)))
+	}
+	if len(f.types) != 0 {
+		filters = append(filters, newFilter(volume_type_key, f.types...))
+	}
+	if len(f.azs) != 0 {
+		filters = append(filters, newFilter(az_key, f.azs...))
+	}
+	return append(filters, pushdownFilters(f.where, volumeFilterNames)...)
+}
+
+func (f *VolumeListFilter) applyCustomFilter(volume *ec2.Volume) bool {
+	size := aws.Int64Value(volume.Size)
+	if f.minSize != 0 && size < f.minSize {
+		return false
+	}
+	if f.maxSize != 0 && size > f.maxSize {
+		return false
+	}
+	return f.where == nil || f.where.Match(volumeRecord(volume))
+}
+
+type SnapshotListFilter struct {
+	owners     []string
+	volumeIds  []string
+	startRange *ctltime.Range
+	now        time.Time
+	where      *expr.Expression
+}
+
+func (f *SnapshotListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.volumeIds) != 0 {
+		filters = append(filters, newFilter(volume_id_key, f.volumeIds...))
+	}
+	return append(filters, pushdownFilters(f.where, snapshotFilterNames)...)
+}
+
+func (f *SnapshotListFilter) applyCustomFilter(snapshot *ec2.Snapshot) bool {
+	if f.startRange != nil && !f.startRange.Contains(aws.TimeValue(snapshot.StartTime)) {
+		return false
+	}
+	return f.where == nil || f.where.Match(snapshotRecord(snapshot))
+}
+
+type ImageListFilter struct {
+	owners        []string
+	names         []string
+	architectures []string
+	// images created at or after are skipped, zero means no limit
+	olderThan time.Time
+	now       time.Time
+	where     *expr.Expression
+}
+
+func (f *ImageListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.names) != 0 {
+		filters = append(filters, newFilter(name_key, f.names...))
+	}
+	if len(f.architectures) != 0 {
+		filters = append(filters, newFilter(architecture_key, f.architectures...))
+	}
+	return append(filters, pushdownFilters(f.where, imageFilterNames)...)
+}
+
+func (f *ImageListFilter) applyCustomFilter(image *ec2.Image) bool {
+	if f.where != nil && !f.where.Match(imageRecord(image)) {
+		return false
+	}
+	if f.olderThan.IsZero() {
+		return true
+	}
+	creationDate, err := time.Parse(time.RFC3339, aws.StringValue(image.CreationDate))
+	return err == nil && creationDate.Before(f.olderThan)
+}
+
+type NetworkInterfaceListFilter struct {
+	available      bool
+	vpcIds         []string
+	subnetIds      []string
+	interfaceTypes []string
+}
+
+func (f *NetworkInterfaceListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if f.available {
+		filters = append(filters, newFilter(status_key, ec2.NetworkInterfaceStatusAvailable))
+	}
+	if len(f.vpcIds) != 0 {
+		filters = append(filters, newFilter(vpc_id_key, f.vpcIds...))
+	}
+	if len(f.subnetIds) != 0 {
+		filters = append(filters, newFilter(subnet_id_key, f.subnetIds...))
+	}
+	if len(f.interfaceTypes) != 0 {
+		filters = append(filters, newFilter(interface_type_key, f.interfaceTypes...))
+	}
+	return filters
+}
+
+type InstanceTypeListFilter struct {
+	types             []string
+	architectures     []string
+	currentGeneration bool
+	ebsOptimized      bool
+	noGpu             bool
+	network           string
+	// 0 means no limit, memory is in GiB
+	minVcpus  int64
+	maxVcpus  int64
+	minMemory float64
+	maxMemory float64
+}
+
+func (f *InstanceTypeListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.types) != 0 {
+		filters = append(filters, newFilter(instance_type_key, f.types...))
+	}
+	if len(f.architectures) != 0 {
+		filters = append(filters, newFilter(supported_arch_key, f.architectures...))
+	}
+	if f.currentGeneration {
+		filters = append(filters, newFilter(current_generation_key, "true"))
+	}
+	if f.ebsOptimized {
+		filters = append(filters, newFilter(ebs_optimized_key, ec2.EbsOptimizedSupportSupported, ec2.EbsOptimizedSupportDefault))
+	}
+	return filters
+}
+
+// applyCustomFilter ranges & substring match aren't supported by api filters
+func (f *InstanceTypeListFilter) applyCustomFilter(summary *instanceTypeSummary) bool {
+	if f.minVcpus != 0 && summary.vcpus < f.minVcpus {
+		return false
+	}
+	if f.maxVcpus != 0 && summary.vcpus > f.maxVcpus {
+		return false
+	}
+	if f.minMemory != 0 && summary.memory < f.minMemory {
+		return false
+	}
+	if f.maxMemory != 0 && summary.memory > f.maxMemory {
+		return false
+	}
+	if f.noGpu && summary.gpus != 0 {
+		return false
+	}
+	if len(f.network) != 0 && !strings.Contains(strings.ToLower(summary.network), strings.ToLower(f.network)) {
+		return false
+	}
+	return true
 }
 
 func (f *InstanceListFilter) applyCustomFilter(instance *ec2.Instance) bool {
 	if f.hasPublicIp != nil && instance.PublicIpAddress == nil {
 		return false
 	}
-	return true
+	if len(f.lifecycles) != 0 && !containsString(f.lifecycles, instanceLifecycle(instance)) {
+		return false
+	}
+	// launch-time api filter supports only wildcard, so range is filtered after fetch
+	if f.launchRange != nil && !f.launchRange.Contains(aws.TimeValue(instance.LaunchTime)) {
+		return false
+	}
+	return f.where == nil || f.where.Match(instanceRecord(instance))
+}
+
+// applyStatusFilter status is fetched separately from instance, so it is filtered after the status fetch
+func (f *InstanceListFilter) applyStatusFilter(status *instanceStatus) bool {
+	return !f.impaired || status.isImpaired()
 }
 
 func (f *InstanceListFilter) requestFilters() []*ec2.Filter {
@@ -59,6 +327,14 @@ func (f *InstanceListFilter) requestFilters() []*ec2.Filter {
 	if launchAtFilter != nil {
 		filters = append(filters, launchAtFilter)
 	}
+	if len(f.asgNames) != 0 {
+		filters = append(filters, newFilter(tag_key_prefix+asg_name_tag_key, f.asgNames...))
+	}
+	// on-demand instances have no lifecycle attribute to filter on, so they are filtered after fetch
+	if len(f.lifecycles) != 0 && !containsString(f.lifecycles, LIFECYCLE_ON_DEMAND) {
+		filters = append(filters, newFilter(instance_lifecycle_key, f.lifecycles...))
+	}
+	filters = append(filters, pushdownFilters(f.where, instanceFilterNames)...)
 	// log.Default().Println("requestFilters ==> ", filters)
 	// log.Default().Println("customFilter ==> ", filters)
 	return filters
@@ -154,6 +430,37 @@ func (f *InstanceListFilter) launchAtFilter() *ec2.Filter {
 	return filter
 }
 
+func newFilter(name string, values ...string) *ec2.Filter {
+	return &ec2.Filter{Name: aws.String(name), Values: aws.StringSlice(values)}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// activeInstanceStateFilter exclude terminated instances
+func activeInstanceStateFilter() *ec2.Filter {
+	return newFilter(instance_state_name_key, "pending", "running", "shutting-down", "stopping", "stopped")
+}
+
+// tagFilters return tag:<key> filter for each tag, tag-key filter if tag value is empty
+func tagFilters(tags map[string]string) []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	for key, value := range tags {
+		if len(value) == 0 {
+			filters = append(filters, &ec2.Filter{Name: aws.String(tag_key_key), Values: []*string{aws.String(key)}})
+			continue
+		}
+		filters = append(filters, &ec2.Filter{Name: aws.String(tag_key_prefix + key), Values: []*string{aws.String(value)}})
+	}
+	return filters
+}
+
 func NewInstanceFilter(optfuncs ...InstanceListFilterOptFunc) *InstanceListFilter {
 	filter := &InstanceListFilter{
 		hasPublicIp: nil,
@@ -194,14 +501,422 @@ func WithSubnetsIds(subnetIds []string) InstanceListFilterOptFunc {
 	}
 }
 
+func WithAsgNames(asgNames []string) InstanceListFilterOptFunc {
+	return func(filter *InstanceListFilter) {
+		filter.asgNames = asgNames
+	}
+}
+
+func WithLifecycles(lifecycles []string) InstanceListFilterOptFunc {
+	return func(filter *InstanceListFilter) {
+		filter.lifecycles = lifecycles
+	}
+}
+
 func WithLaunchAt(time string) InstanceListFilterOptFunc {
 	return func(filter *InstanceListFilter) {
 		filter.launchAt = &time
 	}
 }
 
+func WithLaunchRange(launchRange *ctltime.Range) InstanceListFilterOptFunc {
+	return func(filter *InstanceListFilter) {
+		filter.launchRange = launchRange
+	}
+}
+
 func WithHasPublicIp() InstanceListFilterOptFunc {
 	return func(filter *InstanceListFilter) {
 		filter.hasPublicIp = aws.Bool(true)
 	}
 }
+
+// WithWhere filter instances by --where expression, its equalities are pushed down to api filters
+func WithWhere(where *expr.Expression) InstanceListFilterOptFunc {
+	return func(filter *InstanceListFilter) {
+		filter.where = where
+	}
+}
+
+func WithImpaired() InstanceListFilterOptFunc {
+	return func(filter *InstanceListFilter) {
+		filter.impaired = true
+	}
+}
+
+func NewSecurityGroupFilter(optfuncs ...SecurityGroupListFilterOptFunc) *SecurityGroupListFilter {
+	filter := &SecurityGroupListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithSecurityGroupVpcIds(vpcIds []string) SecurityGroupListFilterOptFunc {
+	return func(filter *SecurityGroupListFilter) {
+		filter.vpcIds = vpcIds
+	}
+}
+
+// WithSecurityGroupNames group name supports wildcard (*), for example, web-*
+func WithSecurityGroupNames(names []string) SecurityGroupListFilterOptFunc {
+	return func(filter *SecurityGroupListFilter) {
+		filter.groupNames = names
+	}
+}
+
+func WithSecurityGroupTags(tags map[string]string) SecurityGroupListFilterOptFunc {
+	return func(filter *SecurityGroupListFilter) {
+		filter.tags = tags
+	}
+}
+
+func NewSubnetFilter(optfuncs ...SubnetListFilterOptFunc) *SubnetListFilter {
+	filter := &SubnetListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithSubnetVpcIds(vpcIds []string) SubnetListFilterOptFunc {
+	return func(filter *SubnetListFilter) {
+		filter.vpcIds = vpcIds
+	}
+}
+
+func WithSubnetAvailabilityZones(azs []string) SubnetListFilterOptFunc {
+	return func(filter *SubnetListFilter) {
+		filter.azs = azs
+	}
+}
+
+func NewVolumeFilter(optfuncs ...VolumeListFilterOptFunc) *VolumeListFilter {
+	filter := &VolumeListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithVolumeUnattached() VolumeListFilterOptFunc {
+	return func(filter *VolumeListFilter) {
+		filter.unattached = true
+	}
+}
+
+func WithVolumeEncrypted(encrypted bool) VolumeListFilterOptFunc {
+	return func(filter *VolumeListFilter) {
+		filter.encrypted = &encrypted
+	}
+}
+
+func WithVolumeTypes(types []string) VolumeListFilterOptFunc {
+	return func(filter *VolumeListFilter) {
+		filter.types = types
+	}
+}
+
+func WithVolumeAvailabilityZones(azs []string) VolumeListFilterOptFunc {
+	return func(filter *VolumeListFilter) {
+		filter.azs = azs
+	}
+}
+
+func WithVolumeSizeRange(minSize, maxSize int64) VolumeListFilterOptFunc {
+	return func(filter *VolumeListFilter) {
+		filter.minSize = minSize
+		filter.maxSize = maxSize
+	}
+}
+
+func WithVolumeWhere(where *expr.Expression) VolumeListFilterOptFunc {
+	return func(filter *VolumeListFilter) {
+		filter.where = where
+	}
+}
+
+// NewSnapshotFilter default owner is self, listing all accessible snapshots include public ones
+func NewSnapshotFilter(optfuncs ...SnapshotListFilterOptFunc) *SnapshotListFilter {
+	filter := &SnapshotListFilter{owners: []string{"self"}, now: time.Now()}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithSnapshotOwners(owners []string) SnapshotListFilterOptFunc {
+	return func(filter *SnapshotListFilter) {
+		if len(owners) != 0 {
+			filter.owners = owners
+		}
+	}
+}
+
+func WithSnapshotVolumeIds(volumeIds []string) SnapshotListFilterOptFunc {
+	return func(filter *SnapshotListFilter) {
+		filter.volumeIds = volumeIds
+	}
+}
+
+func WithSnapshotStartRange(startRange *ctltime.Range) SnapshotListFilterOptFunc {
+	return func(filter *SnapshotListFilter) {
+		filter.startRange = startRange
+	}
+}
+
+func WithSnapshotWhere(where *expr.Expression) SnapshotListFilterOptFunc {
+	return func(filter *SnapshotListFilter) {
+		filter.where = where
+	}
+}
+
+// NewImageFilter default owner is self, public images are too many to list
+func NewImageFilter(optfuncs ...ImageListFilterOptFunc) *ImageListFilter {
+	filter := &ImageListFilter{owners: []string{"self"}, now: time.Now()}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithImageOwners(owners []string) ImageListFilterOptFunc {
+	return func(filter *ImageListFilter) {
+		if len(owners) != 0 {
+			filter.owners = owners
+		}
+	}
+}
+
+func WithImageNames(names []string) ImageListFilterOptFunc {
+	return func(filter *ImageListFilter) {
+		filter.names = names
+	}
+}
+
+func WithImageArchitectures(architectures []string) ImageListFilterOptFunc {
+	return func(filter *ImageListFilter) {
+		filter.architectures = architectures
+	}
+}
+
+func WithImageOlderThan(olderThan time.Time) ImageListFilterOptFunc {
+	return func(filter *ImageListFilter) {
+		filter.olderThan = olderThan
+	}
+}
+
+func WithImageWhere(where *expr.Expression) ImageListFilterOptFunc {
+	return func(filter *ImageListFilter) {
+		filter.where = where
+	}
+}
+
+func NewNetworkInterfaceFilter(optfuncs ...NetworkInterfaceListFilterOptFunc) *NetworkInterfaceListFilter {
+	filter := &NetworkInterfaceListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithNetworkInterfaceAvailable() NetworkInterfaceListFilterOptFunc {
+	return func(filter *NetworkInterfaceListFilter) {
+		filter.available = true
+	}
+}
+
+func WithNetworkInterfaceVpcIds(vpcIds []string) NetworkInterfaceListFilterOptFunc {
+	return func(filter *NetworkInterfaceListFilter) {
+		filter.vpcIds = vpcIds
+	}
+}
+
+func WithNetworkInterfaceSubnetIds(subnetIds []string) NetworkInterfaceListFilterOptFunc {
+	return func(filter *NetworkInterfaceListFilter) {
+		filter.subnetIds = subnetIds
+	}
+}
+
+func WithNetworkInterfaceTypes(interfaceTypes []string) NetworkInterfaceListFilterOptFunc {
+	return func(filter *NetworkInterfaceListFilter) {
+		filter.interfaceTypes = interfaceTypes
+	}
+}
+
+func NewInstanceTypeFilter(optfuncs ...InstanceTypeListFilterOptFunc) *InstanceTypeListFilter {
+	filter := &InstanceTypeListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithInstanceTypeNames(types []string) InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.types = types
+	}
+}
+
+func WithInstanceTypeArchitectures(architectures []string) InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.architectures = architectures
+	}
+}
+
+func WithInstanceTypeVcpuRange(minVcpus, maxVcpus int64) InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.minVcpus = minVcpus
+		filter.maxVcpus = maxVcpus
+	}
+}
+
+func WithInstanceTypeMemoryRange(minMemory, maxMemory float64) InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.minMemory = minMemory
+		filter.maxMemory = maxMemory
+	}
+}
+
+func WithInstanceTypeNetwork(network string) InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.network = network
+	}
+}
+
+func WithInstanceTypeCurrentGeneration() InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.currentGeneration = true
+	}
+}
+
+func WithInstanceTypeEbsOptimized() InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.ebsOptimized = true
+	}
+}
+
+func WithInstanceTypeNoGpu() InstanceTypeListFilterOptFunc {
+	return func(filter *InstanceTypeListFilter) {
+		filter.noGpu = true
+	}
+}
+
+type LaunchTemplateListFilter struct {
+	names []string
+}
+
+func (f *LaunchTemplateListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.names) != 0 {
+		filters = append(filters, newFilter(launch_template_name_key, f.names...))
+	}
+	return filters
+}
+
+func NewLaunchTemplateFilter(optfuncs ...LaunchTemplateListFilterOptFunc) *LaunchTemplateListFilter {
+	filter := &LaunchTemplateListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+// WithLaunchTemplateNames names support wildcard (*) e.g. web-*
+func WithLaunchTemplateNames(names []string) LaunchTemplateListFilterOptFunc {
+	return func(filter *LaunchTemplateListFilter) {
+		filter.names = names
+	}
+}
+
+type SpotRequestListFilter struct {
+	states []string
+}
+
+func (f *SpotRequestListFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.states) != 0 {
+		filters = append(filters, newFilter(state_key, f.states...))
+	}
+	return filters
+}
+
+func NewSpotRequestFilter(optfuncs ...SpotRequestListFilterOptFunc) *SpotRequestListFilter {
+	filter := &SpotRequestListFilter{}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithSpotRequestStates(states []string) SpotRequestListFilterOptFunc {
+	return func(filter *SpotRequestListFilter) {
+		filter.states = states
+	}
+}
+
+type SpotPriceHistoryFilter struct {
+	instanceTypes []string
+	azs           []string
+	products      []string
+	// nil or zero since means DEFAULT_SPOT_PRICE_PERIOD before end
+	priceRange *ctltime.Range
+	now        time.Time
+}
+
+func (f *SpotPriceHistoryFilter) requestFilters() []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if len(f.azs) != 0 {
+		filters = append(filters, newFilter(az_key, f.azs...))
+	}
+	return filters
+}
+
+func (f *SpotPriceHistoryFilter) startTime() time.Time {
+	if f.priceRange == nil || f.priceRange.Since.IsZero() {
+		return f.endTime().Add(-DEFAULT_SPOT_PRICE_PERIOD)
+	}
+	return f.priceRange.Since
+}
+
+func (f *SpotPriceHistoryFilter) endTime() time.Time {
+	if f.priceRange == nil || f.priceRange.Until.IsZero() {
+		return f.now
+	}
+	return f.priceRange.Until
+}
+
+// NewSpotPriceHistoryFilter default is Linux/UNIX price of last 7 days
+func NewSpotPriceHistoryFilter(optfuncs ...SpotPriceHistoryFilterOptFunc) *SpotPriceHistoryFilter {
+	filter := &SpotPriceHistoryFilter{products: []string{DEFAULT_SPOT_PRODUCT}, now: time.Now()}
+	for _, optfunc := range optfuncs {
+		optfunc(filter)
+	}
+	return filter
+}
+
+func WithSpotPriceInstanceTypes(instanceTypes []string) SpotPriceHistoryFilterOptFunc {
+	return func(filter *SpotPriceHistoryFilter) {
+		filter.instanceTypes = instanceTypes
+	}
+}
+
+func WithSpotPriceAvailabilityZones(azs []string) SpotPriceHistoryFilterOptFunc {
+	return func(filter *SpotPriceHistoryFilter) {
+		filter.azs = azs
+	}
+}
+
+func WithSpotPriceProducts(products []string) SpotPriceHistoryFilterOptFunc {
+	return func(filter *SpotPriceHistoryFilter) {
+		if len(products) != 0 {
+			filter.products = products
+		}
+	}
+}
+
+func WithSpotPriceRange(priceRange *ctltime.Range) SpotPriceHistoryFilterOptFunc {
+	return func(filter *SpotPriceHistoryFilter) {
+		filter.priceRange = priceRange
+	}
+}
@@ -0,0 +1,160 @@
+package ec2
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	DEFAULT_LAUNCH_TEMPLATE_VERSION = "$Default"
+	LATEST_LAUNCH_TEMPLATE_VERSION  = "$Latest"
+
+	LAUNCH_TEMPLATE_FIELD_ADDED    = "added"
+	LAUNCH_TEMPLATE_FIELD_REMOVED  = "removed"
+	LAUNCH_TEMPLATE_FIELD_MODIFIED = "modified"
+)
+
+var (
+	// list elements having one of these fields, or scalar elements, are keyed by value instead of index, so reordering isn't reported as change
+	launchTemplateListKeyFields = []string{"DeviceName", "DeviceIndex", "ResourceType", "Key"}
+)
+
+type launchTemplateField struct {
+	name  string
+	value string
+}
+
+type launchTemplateFieldChange struct {
+	field  string
+	from   string
+	to     string
+	change string
+}
+
+// isLaunchTemplateId return true if value is launch template id, otherwise it is template name
+func isLaunchTemplateId(value string) bool {
+	return strings.HasPrefix(value, "lt-")
+}
+
+// flattenLaunchTemplateData flatten template data into field paths like BlockDeviceMappings[/dev/xvda].Ebs.VolumeSize,
+// user data is excluded as it is compared after decoding
+func flattenLaunchTemplateData(data *ec2.ResponseLaunchTemplateData) []*launchTemplateField {
+	fields := []*launchTemplateField{}
+	if data == nil {
+		return fields
+	}
+	copied := *data
+	copied.UserData = nil
+	flattenValue("", reflect.ValueOf(copied), &fields)
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].name < fields[j].name
+	})
+	return fields
+}
+
+func flattenValue(path string, value reflect.Value, fields *[]*launchTemplateField) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return
+		}
+		if t, ok := value.Interface().(*time.Time); ok {
+			*fields = append(*fields, &launchTemplateField{name: path, value: t.UTC().Format(time.RFC3339)})
+			return
+		}
+		flattenValue(path, value.Elem(), fields)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			// sdk structs have unexported _ field for metadata
+			if field.PkgPath != "" {
+				continue
+			}
+			name := field.Name
+			if len(path) != 0 {
+				name = path + "." + field.Name
+			}
+			flattenValue(name, value.Field(i), fields)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			element := value.Index(i)
+			flattenValue(fmt.Sprintf("%s[%s]", path, listElementKey(element, i)), element, fields)
+		}
+	default:
+		*fields = append(*fields, &launchTemplateField{name: path, value: fmt.Sprint(value.Interface())})
+	}
+}
+
+func listElementKey(element reflect.Value, index int) string {
+	for element.Kind() == reflect.Ptr && !element.IsNil() {
+		element = element.Elem()
+	}
+	switch element.Kind() {
+	case reflect.Struct:
+	case reflect.String, reflect.Bool, reflect.Int64, reflect.Float64:
+		return fmt.Sprint(element.Interface())
+	default:
+		return fmt.Sprint(index)
+	}
+	for _, keyField := range launchTemplateListKeyFields {
+		key := element.FieldByName(keyField)
+		if key.IsValid() && key.Kind() == reflect.Ptr && !key.IsNil() {
+			return fmt.Sprint(key.Elem().Interface())
+		}
+	}
+	return fmt.Sprint(index)
+}
+
+// diffLaunchTemplateFields compare flattened fields of two versions, only changed fields are returned
+func diffLaunchTemplateFields(from, to []*launchTemplateField) []*launchTemplateFieldChange {
+	fromValues, toValues := map[string]string{}, map[string]string{}
+	names := []string{}
+	for _, field := range from {
+		fromValues[field.name] = field.value
+		names = append(names, field.name)
+	}
+	for _, field := range to {
+		toValues[field.name] = field.value
+		if _, ok := fromValues[field.name]; !ok {
+			names = append(names, field.name)
+		}
+	}
+	sort.Strings(names)
+	changes := []*launchTemplateFieldChange{}
+	for _, name := range names {
+		fromValue, inFrom := fromValues[name]
+		toValue, inTo := toValues[name]
+		switch {
+		case !inFrom:
+			changes = append(changes, &launchTemplateFieldChange{field: name, from: NO_VALUE, to: toValue, change: LAUNCH_TEMPLATE_FIELD_ADDED})
+		case !inTo:
+			changes = append(changes, &launchTemplateFieldChange{field: name, from: fromValue, to: NO_VALUE, change: LAUNCH_TEMPLATE_FIELD_REMOVED})
+		case fromValue != toValue:
+			changes = append(changes, &launchTemplateFieldChange{field: name, from: fromValue, to: toValue, change: LAUNCH_TEMPLATE_FIELD_MODIFIED})
+		}
+	}
+	return changes
+}
+
+// launchTemplateUserData return decoded user data of a version, parts are joined so it can be compared line by line
+func launchTemplateUserData(data *ec2.ResponseLaunchTemplateData) ([]*userDataPart, string, error) {
+	if data == nil || len(awssdk.StringValue(data.UserData)) == 0 {
+		return nil, "", nil
+	}
+	parts, err := decodeUserData(*data.UserData)
+	if err != nil {
+		return nil, "", err
+	}
+	contents := []string{}
+	for _, part := range parts {
+		contents = append(contents, part.content)
+	}
+	return parts, strings.Join(contents, "\n"), nil
+}
@@ -0,0 +1,161 @@
+package ec2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	GROUP_BY_STATE  = "state"
+	GROUP_BY_TYPE   = "type"
+	GROUP_BY_AZ     = "az"
+	GROUP_BY_VPC    = "vpc"
+	GROUP_BY_SUBNET = "subnet"
+	GROUP_BY_NONE   = "none"
+	GROUP_BY_TAG    = "tag:"
+
+	SORT_BY_LAUNCH = "launch"
+	SORT_BY_TYPE   = "type"
+	SORT_BY_ID     = "id"
+
+	SORT_ORDER_ASC  = "asc"
+	SORT_ORDER_DESC = "desc"
+)
+
+type InstanceListViewOptFunc func(*InstanceListView)
+
+// InstanceListView control how listed instances are grouped, sorted & rendered
+type InstanceListView struct {
+	groupBy    *InstanceGroupBy
+	summary    bool
+	sortBy     string
+	descending bool
+}
+
+// InstanceGroupBy is an instance field or a tag key, e.g. az or tag:env
+type InstanceGroupBy struct {
+	field  string
+	tagKey string
+}
+
+// ParseInstanceGroupBy parse state | type | az | vpc | subnet | tag:<key> | none
+func ParseInstanceGroupBy(value string) (*InstanceGroupBy, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case GROUP_BY_STATE, GROUP_BY_TYPE, GROUP_BY_AZ, GROUP_BY_VPC, GROUP_BY_SUBNET, GROUP_BY_NONE:
+		return &InstanceGroupBy{field: value}, nil
+	}
+	if strings.HasPrefix(value, GROUP_BY_TAG) && len(value) > len(GROUP_BY_TAG) {
+		return &InstanceGroupBy{field: GROUP_BY_TAG, tagKey: strings.TrimPrefix(value, GROUP_BY_TAG)}, nil
+	}
+	return nil, InvalidGroupBy(value)
+}
+
+func (g *InstanceGroupBy) String() string {
+	if g.field == GROUP_BY_TAG {
+		return GROUP_BY_TAG + g.tagKey
+	}
+	return g.field
+}
+
+// key return group of instance, NO_VALUE if instance isn't tagged with tag key
+func (g *InstanceGroupBy) key(instance *instanceSummary) string {
+	switch g.field {
+	case GROUP_BY_STATE:
+		return *instance.state
+	case GROUP_BY_TYPE:
+		return *instance.typee
+	case GROUP_BY_AZ:
+		return *instance.az
+	case GROUP_BY_VPC:
+		return *instance.vpcId
+	case GROUP_BY_SUBNET:
+		return *instance.subnetId
+	case GROUP_BY_TAG:
+		if value, ok := instance.tags[g.tagKey]; ok {
+			return value
+		}
+		return NO_VALUE
+	}
+	return ""
+}
+
+// NewInstanceListView default is one table per state, in order returned by api
+func NewInstanceListView(optfuncs ...InstanceListViewOptFunc) *InstanceListView {
+	view := &InstanceListView{groupBy: &InstanceGroupBy{field: GROUP_BY_STATE}}
+	for _, optfunc := range optfuncs {
+		optfunc(view)
+	}
+	return view
+}
+
+func WithGroupBy(groupBy *InstanceGroupBy) InstanceListViewOptFunc {
+	return func(view *InstanceListView) {
+		if groupBy != nil {
+			view.groupBy = groupBy
+		}
+	}
+}
+
+func WithSummary() InstanceListViewOptFunc {
+	return func(view *InstanceListView) {
+		view.summary = true
+	}
+}
+
+func WithSortBy(sortBy, order string) InstanceListViewOptFunc {
+	return func(view *InstanceListView) {
+		view.sortBy = sortBy
+		view.descending = order == SORT_ORDER_DESC
+	}
+}
+
+func (v *InstanceListView) sortInstances(instances []*instanceSummary) {
+	less := map[string]func(a, b *instanceSummary) bool{
+		SORT_BY_LAUNCH: func(a, b *instanceSummary) bool { return a.launchTime.Before(*b.launchTime) },
+		SORT_BY_TYPE:   func(a, b *instanceSummary) bool { return *a.typee < *b.typee },
+		SORT_BY_ID:     func(a, b *instanceSummary) bool { return *a.id < *b.id },
+	}[v.sortBy]
+	if less == nil {
+		return
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		if v.descending {
+			return less(instances[j], instances[i])
+		}
+		return less(instances[i], instances[j])
+	})
+}
+
+// groupInstances group instances keeping their order, groups are sorted by name and instances without group are last
+func (v *InstanceListView) groupInstances(instances []*instanceSummary) []*instanceGroup {
+	groupsByName := map[string]*instanceGroup{}
+	groups := []*instanceGroup{}
+	for _, instance := range instances {
+		name := v.groupBy.key(instance)
+		group, ok := groupsByName[name]
+		if !ok {
+			group = &instanceGroup{name: name}
+			groupsByName[name] = group
+			groups = append(groups, group)
+		}
+		group.instances = append(group.instances, instance)
+		group.vcpus += instance.vcpus
+		group.memory += instance.memory
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].name == NO_VALUE) != (groups[j].name == NO_VALUE) {
+			return groups[j].name == NO_VALUE
+		}
+		return groups[i].name < groups[j].name
+	})
+	return groups
+}
+
+func (g *instanceGroup) title() string {
+	if len(g.name) == 0 {
+		return "Instances"
+	}
+	return fmt.Sprintf("Instances[%s]", g.name)
+}
@@ -3,10 +3,13 @@ package ec2
 import (
 	"cloudctl/provider/aws"
 	ctltime "cloudctl/time"
+	"cloudctl/viewer"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
@@ -40,6 +43,8 @@ type instanceDetail struct {
 	monitor    *string
 	osdetails  *string
 	launchTime *time.Time
+	vcpus      string
+	memory     string
 }
 type instanceSummary struct {
 	id           *string
@@ -52,8 +57,22 @@ type instanceSummary struct {
 	typee        *string
 	vpcId        *string
 	subnetId     *string
+	asg          string
+	lifecycle    string
+	tags         map[string]string
+	vcpus        int64
 	iamroleArn   *string
 	launchTime   *time.Time
+	status       *instanceStatus
+	// memory in GiB, set only when summarizing
+	memory float64
+}
+
+type instanceStatus struct {
+	systemStatus   string
+	instanceStatus string
+	events         []string
+	uptime         string
 }
 
 type volumeAttachment struct {
@@ -98,12 +117,463 @@ type instanceDefinition struct {
 	volumesSummary    *instanceVolumeSummary
 	ruleSummary       *instanceIngressEgressRuleSummary
 	networkInterfaces []*instanceNetworkinterface
+	health            *instanceHealth
 	err               error
 }
 
+type instanceHealth struct {
+	status   *instanceStatus
+	apiError *aws.ErrorInfo
+}
+
+type securityGroupSummary struct {
+	id          *string
+	name        *string
+	vpcId       *string
+	description *string
+	ingressRule int
+	egressRule  int
+	tags        map[string]string
+}
+
+type securityGroupListOutput struct {
+	securityGroups []*securityGroupSummary
+	err            *aws.ErrorInfo
+}
+
+type securityGroupNetworkInterface struct {
+	id            *string
+	interfaceType *string
+	status        *string
+	privateIp     *string
+	instanceId    *string
+	description   *string
+}
+
+type securityGroupInstance struct {
+	id    *string
+	name  *string
+	state *string
+	typee *string
+}
+
+// securityGroupReference a rule of another group which uses the group as source/destination
+type securityGroupReference struct {
+	sgId      *string
+	direction string
+	portRange *string
+	protocol  *string
+}
+
+type securityGroupDefinition struct {
+	summary           *securityGroupSummary
+	ruleSummary       *instanceIngressEgressRuleSummary
+	networkInterfaces []*securityGroupNetworkInterface
+	instances         []*securityGroupInstance
+	referencedBy      []*securityGroupReference
+	err               *aws.ErrorInfo
+}
+
+type securityGroupAuditOutput struct {
+	regions       []string
+	scannedGroups int
+	findings      []*securityGroupFinding
+	// regions which couldn't be audited, viewed along findings of other regions
+	regionErrs []*aws.ErrorInfo
+	err        *aws.ErrorInfo
+}
+
+type vpcSummary struct {
+	id        *string
+	name      string
+	cidrs     []string
+	state     *string
+	isDefault bool
+	subnets   int
+	instances int
+}
+
+type vpcListOutput struct {
+	vpcs []*vpcSummary
+	err  *aws.ErrorInfo
+}
+
+type subnetSummary struct {
+	id                  *string
+	name                string
+	vpcId               *string
+	az                  *string
+	cidr                *string
+	availableIps        *int64
+	mapPublicIpOnLaunch bool
+	routeTableId        string
+	instances           int
+}
+
+type subnetListOutput struct {
+	subnets []*subnetSummary
+	err     *aws.ErrorInfo
+}
+
+type routeTableRoute struct {
+	destination string
+	target      string
+	state       string
+}
+
+type vpcRouteTable struct {
+	id           *string
+	name         string
+	main         bool
+	associations []string
+	routes       []*routeTableRoute
+}
+
+type vpcGateway struct {
+	id        *string
+	name      string
+	gwType    string
+	state     string
+	subnetId  string
+	publicIp  string
+	privateIp string
+}
+
+type vpcEndpoint struct {
+	id          *string
+	serviceName *string
+	typee       *string
+	state       *string
+}
+
+type vpcPeeringConnection struct {
+	id        *string
+	requester string
+	accepter  string
+	status    string
+}
+
+type vpcDefinition struct {
+	summary            *vpcSummary
+	subnets            []*subnetSummary
+	routeTables        []*vpcRouteTable
+	gateways           []*vpcGateway
+	endpoints          []*vpcEndpoint
+	peeringConnections []*vpcPeeringConnection
+	err                *aws.ErrorInfo
+}
+
+type volumeSummary struct {
+	id         *string
+	name       string
+	typee      *string
+	size       int64
+	iops       string
+	state      *string
+	az         *string
+	encrypted  bool
+	attachedTo string
+	createTime *time.Time
+}
+
+type volumeListOutput struct {
+	volumes   []*volumeSummary
+	totalSize int64
+	err       *aws.ErrorInfo
+}
+
+type snapshotSummary struct {
+	id          *string
+	name        string
+	volumeId    *string
+	size        int64
+	state       *string
+	progress    string
+	encrypted   bool
+	description string
+	startTime   *time.Time
+	age         string
+}
+
+type snapshotListOutput struct {
+	snapshots []*snapshotSummary
+	totalSize int64
+	err       *aws.ErrorInfo
+}
+
+type snapshotCreateOutput struct {
+	instanceId string
+	snapshots  []*snapshotSummary
+	err        *aws.ErrorInfo
+}
+
+type resourceDeletion struct {
+	id  string
+	err *aws.ErrorInfo
+}
+
+type volumeCleanupOutput struct {
+	olderThan    string
+	volumes      []*volumeSummary
+	volumeSize   int64
+	snapshots    []*snapshotSummary
+	snapshotSize int64
+	deletions    []*resourceDeletion
+	err          *aws.ErrorInfo
+}
+
+type imageSummary struct {
+	id           *string
+	name         string
+	creationDate string
+	age          string
+	architecture string
+	rootDevice   string
+	state        *string
+	public       bool
+	snapshots    []string
+	// total size of backing snapshots in GiB
+	size int64
+}
+
+type imageListOutput struct {
+	images []*imageSummary
+	err    *aws.ErrorInfo
+}
+
+type imageBlockDevice struct {
+	device              string
+	snapshotId          string
+	sourceVolumeId      string
+	size                string
+	volumeType          string
+	encrypted           string
+	deleteOnTermination string
+}
+
+type imageDefinition struct {
+	summary         *imageSummary
+	description     string
+	deprecationTime string
+	blockDevices    []*imageBlockDevice
+	instances       []*securityGroupInstance
+	tags            map[string]string
+	err             *aws.ErrorInfo
+}
+
+type imageUnusedOutput struct {
+	images    []*imageSummary
+	olderThan string
+	totalSize int64
+	deletions []*resourceDeletion
+	err       *aws.ErrorInfo
+}
+
+type elasticIpSummary struct {
+	allocationId       string
+	name               string
+	publicIp           *string
+	privateIp          string
+	associationId      string
+	instanceId         string
+	networkInterfaceId string
+	domain             string
+}
+
+type elasticIpListOutput struct {
+	addresses    []*elasticIpSummary
+	unassociated int
+	err          *aws.ErrorInfo
+}
+
+type networkInterfaceSummary struct {
+	id               *string
+	name             string
+	interfaceType    string
+	status           *string
+	instanceId       string
+	instanceOwnerId  string
+	privateIp        string
+	publicIp         string
+	vpcId            string
+	subnetId         string
+	securityGroups   []string
+	requesterManaged bool
+	requesterId      string
+	description      string
+}
+
+type networkInterfaceListOutput struct {
+	networkInterfaces []*networkInterfaceSummary
+	available         int
+	err               *aws.ErrorInfo
+}
+
+type connectOutput struct {
+	instanceId string
+	name       string
+	method     string
+	target     string
+	forward    string
+	err        *aws.ErrorInfo
+}
+
+type consoleOutput struct {
+	instanceId     string
+	content        string
+	screenshotFile string
+	err            *aws.ErrorInfo
+	// time of last console output update, nil when unknown
+	timestamp *time.Time
+}
+
+type userDataOutput struct {
+	instanceId string
+	parts      []*userDataPart
+	err        *aws.ErrorInfo
+}
+
+type instanceTypeSummary struct {
+	typee             string
+	vcpus             int64
+	cores             int64
+	threadsPerCore    int64
+	memory            float64
+	architectures     string
+	clockSpeed        string
+	gpus              int64
+	gpuDetail         string
+	network           string
+	maxEnis           int64
+	ebsOptimized      string
+	ebsBandwidth      string
+	instanceStorage   string
+	burstable         bool
+	currentGeneration bool
+	hypervisor        string
+}
+
+type instanceTypeListOutput struct {
+	instanceTypes []*instanceTypeSummary
+	err           *aws.ErrorInfo
+}
+
+type launchTemplateSummary struct {
+	id             *string
+	name           string
+	defaultVersion int64
+	latestVersion  int64
+	createdBy      string
+	createdTime    *time.Time
+}
+
+type launchTemplateListOutput struct {
+	launchTemplates []*launchTemplateSummary
+	err             *aws.ErrorInfo
+}
+
+type launchTemplateBlockDevice struct {
+	device              string
+	snapshotId          string
+	size                string
+	volumeType          string
+	iops                string
+	throughput          string
+	encrypted           string
+	deleteOnTermination string
+}
+
+type launchTemplateNetworkInterface struct {
+	deviceIndex    string
+	subnetId       string
+	publicIp       string
+	securityGroups string
+}
+
+type launchTemplateDefinition struct {
+	summary            *launchTemplateSummary
+	version            int64
+	versionDescription string
+	versionCreatedBy   string
+	versionCreatedTime *time.Time
+	imageId            string
+	instanceType       string
+	keyName            string
+	iamProfile         string
+	ebsOptimized       string
+	httpTokens         string
+	// id => name, name is resolved from security group ids of template & its network interfaces
+	securityGroups    map[string]string
+	blockDevices      []*launchTemplateBlockDevice
+	networkInterfaces []*launchTemplateNetworkInterface
+	userData          []*userDataPart
+	err               *aws.ErrorInfo
+}
+
+type launchTemplateDiffOutput struct {
+	id           string
+	from         int64
+	to           int64
+	changes      []*launchTemplateFieldChange
+	fromUserData string
+	toUserData   string
+	err          *aws.ErrorInfo
+}
+
+type spotRequestSummary struct {
+	id           *string
+	state        string
+	status       string
+	requestType  string
+	instanceType string
+	az           string
+	maxPrice     string
+	instanceId   string
+	createdTime  *time.Time
+}
+
+type spotRequestListOutput struct {
+	spotRequests []*spotRequestSummary
+	err          *aws.ErrorInfo
+}
+
+type spotPriceSummary struct {
+	az        string
+	typee     string
+	product   string
+	current   float64
+	min       float64
+	max       float64
+	average   float64
+	changes   int
+	sparkline string
+}
+
+type spotPriceHistoryOutput struct {
+	start  time.Time
+	end    time.Time
+	prices []*spotPriceSummary
+	err    *aws.ErrorInfo
+}
+
+type instanceTypeCompareOutput struct {
+	instanceTypes []*instanceTypeSummary
+	err           *aws.ErrorInfo
+}
+
+type instanceGroup struct {
+	name      string
+	instances []*instanceSummary
+	vcpus     int64
+	memory    float64
+}
+
 type instanceListOutput struct {
-	instancesByState map[string][]*instanceSummary
-	err              *aws.ErrorInfo
+	groups     []*instanceGroup
+	groupBy    *InstanceGroupBy
+	summary    bool
+	showStatus bool
+	err        *aws.ErrorInfo
 }
 
 func (summary *instanceSummary) setIAMProfileARN(profile *ec2.IamInstanceProfile) *instanceSummary {
@@ -162,6 +632,18 @@ func newInstanceSummary(instance *ec2.Instance, tz *ctltime.Timezone) *instanceS
 		state:      instance.State.Name,
 		typee:      instance.InstanceType,
 		launchTime: tz.AdaptTimezone(instance.LaunchTime),
+		asg:        NO_VALUE,
+		lifecycle:  instanceLifecycle(instance),
+		tags:       newTags(instance.Tags),
+	}
+	if instance.CpuOptions != nil {
+		instanceSummary.vcpus = awssdk.Int64Value(instance.CpuOptions.CoreCount) * awssdk.Int64Value(instance.CpuOptions.ThreadsPerCore)
+	}
+	// instances launched by auto scaling group are tagged with group name
+	for _, tag := range instance.Tags {
+		if *tag.Key == asg_name_tag_key {
+			instanceSummary.asg = *tag.Value
+		}
 	}
 	instanceSummary.setIAMProfileARN(instance.IamInstanceProfile)
 	instanceSummary.SetNetworkDetail(instance.VpcId, instance.SubnetId)
@@ -181,6 +663,8 @@ func newInstanceDetail(instance *ec2.Instance, tz *ctltime.Timezone) *instanceDe
 		monitor:    instance.Monitoring.State,
 		osdetails:  instance.PlatformDetails,
 		launchTime: tz.AdaptTimezone(instance.LaunchTime),
+		vcpus:      NO_VALUE,
+		memory:     NO_VALUE,
 	}
 }
 func newInstanceVolumeSummary(volumes []*instanceVolume, apiError *aws.ErrorInfo) *instanceVolumeSummary {
@@ -208,19 +692,27 @@ func newInstanceVolume(volume *ec2.Volume) *instanceVolume {
 		state:        volume.State,
 	}
 }
+
+// permissionPortRange return port range & protocol of permission, ALL if IpProtocol is -1
+func permissionPortRange(permission *ec2.IpPermission) (string, string) {
+	if *permission.IpProtocol == "-1" {
+		return "ALL", "ALL"
+	}
+	protocol := strings.ToUpper(*permission.IpProtocol)
+	if permission.FromPort == nil || permission.ToPort == nil {
+		return "ALL", protocol
+	}
+	if *permission.FromPort != *permission.ToPort {
+		return fmt.Sprintf("%d-%d", *permission.FromPort, *permission.ToPort), protocol
+	}
+	return fmt.Sprintf("%d", *permission.FromPort), protocol
+}
+
 func newSecurityIngressRules(securityGroupId, securityGroupName, securityGroupDescription string, ingressPermissions []*ec2.IpPermission) (ingressRules []*ingressRule) {
 	ingressRules = []*ingressRule{}
 	sgIdWithName := fmt.Sprintf("%s(%s)", securityGroupId, securityGroupName)
-	portRange := "ALL" //  if IpProtocol is -1
-	protocol := "ALL"  //  if IpProtocol is -1
 	for _, permission := range ingressPermissions {
-		if *permission.IpProtocol != "-1" {
-			portRange = fmt.Sprintf("%d", *permission.ToPort)
-			if *permission.FromPort != *permission.ToPort {
-				portRange = fmt.Sprintf("%d-%d", *permission.FromPort, *permission.ToPort)
-			}
-			protocol = strings.ToUpper(*permission.IpProtocol)
-		}
+		portRange, protocol := permissionPortRange(permission)
 		for _, userIdGroupPair := range permission.UserIdGroupPairs {
 			description := userIdGroupPair.Description
 			if description == nil {
@@ -270,15 +762,7 @@ func newSecurityEgressRules(securityGroupId, securityGroupName, securityGroupDes
 	egressRules = []*egressRule{}
 	sgIdWithName := fmt.Sprintf("%s(%s)", securityGroupId, securityGroupName)
 	for _, rule := range egressPermissions {
-		portRange := "ALL" // handle if IpProtocol is -1
-		protocol := "ALL"  // handle if IpProtocol is -1
-		if *rule.IpProtocol != "-1" {
-			portRange = fmt.Sprintf("%d", *rule.FromPort)
-			if (rule.FromPort != nil || rule.ToPort != nil) && (*rule.FromPort != *rule.ToPort) {
-				portRange = fmt.Sprintf("%d-%d", *rule.FromPort, *rule.ToPort)
-			}
-			protocol = strings.ToUpper(*rule.IpProtocol)
-		}
+		portRange, protocol := permissionPortRange(rule)
 
 		for _, ipRange := range rule.IpRanges {
 			description := ipRange.Description
@@ -379,7 +863,649 @@ func (def *instanceDefinition) SetInstanceIngressEgressRuleSummary(ruleSummary *
 	return def
 }
 
+// SetInstanceType set vCPU & memory of instance type, instance can have less vCPU if CPU options are customized
+func (def *instanceDefinition) SetInstanceType(instanceType *instanceTypeSummary) *instanceDefinition {
+	def.detail.vcpus = fmt.Sprintf("%d", instanceType.vcpus)
+	def.detail.memory = formatMemory(instanceType.memory)
+	return def
+}
+
+func (def *instanceDefinition) SetHealth(health *instanceHealth) *instanceDefinition {
+	def.health = health
+	return def
+}
+
 func (def *instanceDefinition) SetNetworkInterfaces(interfaces []*instanceNetworkinterface) *instanceDefinition {
 	def.networkInterfaces = interfaces
 	return def
 }
+
+func newSecurityGroupSummary(sg *ec2.SecurityGroup) *securityGroupSummary {
+	description := NO_VALUE
+	if sg.Description != nil {
+		description = *sg.Description
+	}
+	return &securityGroupSummary{
+		id:          sg.GroupId,
+		name:        sg.GroupName,
+		vpcId:       sg.VpcId,
+		description: &description,
+		ingressRule: len(newSecurityIngressRules(*sg.GroupId, *sg.GroupName, description, sg.IpPermissions)),
+		egressRule:  len(newSecurityEgressRules(*sg.GroupId, *sg.GroupName, description, sg.IpPermissionsEgress)),
+		tags:        newTags(sg.Tags),
+	}
+}
+
+func newSecurityGroupNetworkInterface(eni *ec2.NetworkInterface) *securityGroupNetworkInterface {
+	instanceId := NO_VALUE
+	if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
+		instanceId = *eni.Attachment.InstanceId
+	}
+	description := NO_VALUE
+	if eni.Description != nil && len(*eni.Description) != 0 {
+		description = *eni.Description
+	}
+	return &securityGroupNetworkInterface{
+		id:            eni.NetworkInterfaceId,
+		interfaceType: eni.InterfaceType,
+		status:        eni.Status,
+		privateIp:     eni.PrivateIpAddress,
+		instanceId:    &instanceId,
+		description:   &description,
+	}
+}
+
+func newSecurityGroupInstance(instance *ec2.Instance) *securityGroupInstance {
+	name := nameTag(instance.Tags)
+	return &securityGroupInstance{
+		id:    instance.InstanceId,
+		name:  &name,
+		state: instance.State.Name,
+		typee: instance.InstanceType,
+	}
+}
+
+// newSecurityGroupReferences return rules of sg which use groupId as source(ingress) or destination(egress)
+func newSecurityGroupReferences(groupId string, sg *ec2.SecurityGroup) []*securityGroupReference {
+	references := []*securityGroupReference{}
+	sgIdWithName := fmt.Sprintf("%s(%s)", *sg.GroupId, *sg.GroupName)
+	description := awssdk.StringValue(sg.Description)
+	for _, rule := range newSecurityIngressRules(*sg.GroupId, *sg.GroupName, description, sg.IpPermissions) {
+		if *rule.source == groupId {
+			references = append(references, &securityGroupReference{sgId: &sgIdWithName, direction: "Ingress", portRange: rule.portRange, protocol: rule.protocol})
+		}
+	}
+	// egress rules table lists only cidr destinations, so group destinations are read from permissions
+	for _, permission := range sg.IpPermissionsEgress {
+		portRange, protocol := permissionPortRange(permission)
+		for _, pair := range permission.UserIdGroupPairs {
+			if awssdk.StringValue(pair.GroupId) == groupId {
+				references = append(references, &securityGroupReference{sgId: &sgIdWithName, direction: "Egress", portRange: &portRange, protocol: &protocol})
+			}
+		}
+	}
+	return references
+}
+
+func newTags(tags []*ec2.Tag) map[string]string {
+	o := map[string]string{}
+	for _, tag := range tags {
+		o[*tag.Key] = *tag.Value
+	}
+	return o
+}
+
+// nameTag return value of Name tag, NO_VALUE if not tagged
+func nameTag(tags []*ec2.Tag) string {
+	for _, tag := range tags {
+		if *tag.Key == "Name" {
+			return *tag.Value
+		}
+	}
+	return NO_VALUE
+}
+
+// Failure fails the audit command when a critical finding exists or a region couldn't be audited
+func (o *securityGroupAuditOutput) Failure() error {
+	critical := 0
+	for _, finding := range o.findings {
+		if finding.severity == SEVERITY_CRITICAL {
+			critical++
+		}
+	}
+	if critical != 0 {
+		return CriticalSecurityGroupFindings(critical)
+	}
+	if len(o.regionErrs) != 0 {
+		return SecurityGroupAuditIncomplete(len(o.regionErrs))
+	}
+	return nil
+}
+
+func newVpcSummary(vpc *ec2.Vpc) *vpcSummary {
+	cidrs := []string{}
+	for _, association := range vpc.CidrBlockAssociationSet {
+		cidrs = append(cidrs, *association.CidrBlock)
+	}
+	for _, association := range vpc.Ipv6CidrBlockAssociationSet {
+		cidrs = append(cidrs, *association.Ipv6CidrBlock)
+	}
+	return &vpcSummary{
+		id:        vpc.VpcId,
+		name:      nameTag(vpc.Tags),
+		cidrs:     cidrs,
+		state:     vpc.State,
+		isDefault: awssdk.BoolValue(vpc.IsDefault),
+	}
+}
+
+func newSubnetSummary(subnet *ec2.Subnet) *subnetSummary {
+	return &subnetSummary{
+		id:                  subnet.SubnetId,
+		name:                nameTag(subnet.Tags),
+		vpcId:               subnet.VpcId,
+		az:                  subnet.AvailabilityZone,
+		cidr:                subnet.CidrBlock,
+		availableIps:        subnet.AvailableIpAddressCount,
+		mapPublicIpOnLaunch: awssdk.BoolValue(subnet.MapPublicIpOnLaunch),
+		routeTableId:        NO_VALUE,
+	}
+}
+
+func newVpcRouteTable(routeTable *ec2.RouteTable) *vpcRouteTable {
+	o := &vpcRouteTable{
+		id:   routeTable.RouteTableId,
+		name: nameTag(routeTable.Tags),
+	}
+	for _, association := range routeTable.Associations {
+		if awssdk.BoolValue(association.Main) {
+			o.main = true
+		}
+		if association.SubnetId != nil {
+			o.associations = append(o.associations, *association.SubnetId)
+		}
+		if association.GatewayId != nil {
+			o.associations = append(o.associations, *association.GatewayId)
+		}
+	}
+	for _, route := range routeTable.Routes {
+		o.routes = append(o.routes, &routeTableRoute{
+			destination: firstValue(route.DestinationCidrBlock, route.DestinationIpv6CidrBlock, route.DestinationPrefixListId),
+			target: firstValue(route.GatewayId, route.NatGatewayId, route.TransitGatewayId, route.VpcPeeringConnectionId,
+				route.NetworkInterfaceId, route.InstanceId, route.EgressOnlyInternetGatewayId, route.LocalGatewayId, route.CarrierGatewayId, route.CoreNetworkArn),
+			state: awssdk.StringValue(route.State),
+		})
+	}
+	return o
+}
+
+func newInternetGateway(igw *ec2.InternetGateway) *vpcGateway {
+	state := NO_VALUE
+	for _, attachment := range igw.Attachments {
+		state = *attachment.State
+	}
+	return &vpcGateway{
+		id:        igw.InternetGatewayId,
+		name:      nameTag(igw.Tags),
+		gwType:    "internet",
+		state:     state,
+		subnetId:  NO_VALUE,
+		publicIp:  NO_VALUE,
+		privateIp: NO_VALUE,
+	}
+}
+
+func newNatGateway(nat *ec2.NatGateway) *vpcGateway {
+	gateway := &vpcGateway{
+		id:        nat.NatGatewayId,
+		name:      nameTag(nat.Tags),
+		gwType:    fmt.Sprintf("nat(%s)", awssdk.StringValue(nat.ConnectivityType)),
+		state:     awssdk.StringValue(nat.State),
+		subnetId:  awssdk.StringValue(nat.SubnetId),
+		publicIp:  NO_VALUE,
+		privateIp: NO_VALUE,
+	}
+	for _, address := range nat.NatGatewayAddresses {
+		if address.PublicIp != nil {
+			gateway.publicIp = *address.PublicIp
+		}
+		if address.PrivateIp != nil {
+			gateway.privateIp = *address.PrivateIp
+		}
+	}
+	return gateway
+}
+
+func newVpcEndpoint(endpoint *ec2.VpcEndpoint) *vpcEndpoint {
+	return &vpcEndpoint{
+		id:          endpoint.VpcEndpointId,
+		serviceName: endpoint.ServiceName,
+		typee:       endpoint.VpcEndpointType,
+		state:       endpoint.State,
+	}
+}
+
+func newVpcPeeringConnection(peering *ec2.VpcPeeringConnection) *vpcPeeringConnection {
+	vpcInfo := func(info *ec2.VpcPeeringConnectionVpcInfo) string {
+		if info == nil {
+			return NO_VALUE
+		}
+		return fmt.Sprintf("%s(%s) %s", awssdk.StringValue(info.VpcId), awssdk.StringValue(info.CidrBlock), awssdk.StringValue(info.Region))
+	}
+	status := NO_VALUE
+	if peering.Status != nil {
+		status = awssdk.StringValue(peering.Status.Code)
+	}
+	return &vpcPeeringConnection{
+		id:        peering.VpcPeeringConnectionId,
+		requester: vpcInfo(peering.RequesterVpcInfo),
+		accepter:  vpcInfo(peering.AccepterVpcInfo),
+		status:    status,
+	}
+}
+
+// firstValue return first non empty value, NO_VALUE if all are empty
+func firstValue(values ...*string) string {
+	for _, value := range values {
+		if value != nil && len(*value) != 0 {
+			return *value
+		}
+	}
+	return NO_VALUE
+}
+
+func newVolumeSummary(volume *ec2.Volume, tz *ctltime.Timezone) *volumeSummary {
+	summary := &volumeSummary{
+		id:         volume.VolumeId,
+		name:       nameTag(volume.Tags),
+		typee:      volume.VolumeType,
+		size:       awssdk.Int64Value(volume.Size),
+		iops:       NO_VALUE,
+		state:      volume.State,
+		az:         volume.AvailabilityZone,
+		encrypted:  awssdk.BoolValue(volume.Encrypted),
+		attachedTo: NO_VALUE,
+		createTime: tz.AdaptTimezone(volume.CreateTime),
+	}
+	if volume.Iops != nil {
+		summary.iops = fmt.Sprintf("%d", *volume.Iops)
+	}
+	attachments := []string{}
+	for _, attachment := range volume.Attachments {
+		attachments = append(attachments, fmt.Sprintf("%s(%s)", awssdk.StringValue(attachment.InstanceId), awssdk.StringValue(attachment.Device)))
+	}
+	if len(attachments) != 0 {
+		summary.attachedTo = strings.Join(attachments, "\n")
+	}
+	return summary
+}
+
+func newSnapshotSummary(snapshot *ec2.Snapshot, tz *ctltime.Timezone, now time.Time) *snapshotSummary {
+	description := NO_VALUE
+	if len(awssdk.StringValue(snapshot.Description)) != 0 {
+		description = *snapshot.Description
+	}
+	return &snapshotSummary{
+		id:          snapshot.SnapshotId,
+		name:        nameTag(snapshot.Tags),
+		volumeId:    snapshot.VolumeId,
+		size:        awssdk.Int64Value(snapshot.VolumeSize),
+		state:       snapshot.State,
+		progress:    awssdk.StringValue(snapshot.Progress),
+		encrypted:   awssdk.BoolValue(snapshot.Encrypted),
+		description: description,
+		startTime:   tz.AdaptTimezone(snapshot.StartTime),
+		age:         ctltime.FormatAge(now.Sub(awssdk.TimeValue(snapshot.StartTime))),
+	}
+}
+
+func newResourceDeletion(id string, err error) *resourceDeletion {
+	deletion := &resourceDeletion{id: id}
+	if err != nil {
+		deletion.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+	}
+	return deletion
+}
+
+func newImageSummary(image *ec2.Image, tz *ctltime.Timezone, now time.Time) *imageSummary {
+	summary := &imageSummary{
+		id:           image.ImageId,
+		name:         firstValue(image.Name),
+		creationDate: NO_VALUE,
+		age:          NO_VALUE,
+		architecture: awssdk.StringValue(image.Architecture),
+		rootDevice:   fmt.Sprintf("%s(%s)", awssdk.StringValue(image.RootDeviceType), awssdk.StringValue(image.RootDeviceName)),
+		state:        image.State,
+		public:       awssdk.BoolValue(image.Public),
+	}
+	if creationDate, err := time.Parse(time.RFC3339, awssdk.StringValue(image.CreationDate)); err == nil {
+		summary.creationDate = ctltime.Format(*tz.AdaptTimezone(&creationDate))
+		summary.age = ctltime.FormatAge(now.Sub(creationDate))
+	}
+	for _, mapping := range image.BlockDeviceMappings {
+		if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+			summary.snapshots = append(summary.snapshots, *mapping.Ebs.SnapshotId)
+			summary.size += awssdk.Int64Value(mapping.Ebs.VolumeSize)
+		}
+	}
+	return summary
+}
+
+func newImageBlockDevice(mapping *ec2.BlockDeviceMapping) *imageBlockDevice {
+	device := &imageBlockDevice{
+		device:              awssdk.StringValue(mapping.DeviceName),
+		snapshotId:          NO_VALUE,
+		sourceVolumeId:      NO_VALUE,
+		size:                NO_VALUE,
+		volumeType:          NO_VALUE,
+		encrypted:           NO_VALUE,
+		deleteOnTermination: NO_VALUE,
+	}
+	if mapping.VirtualName != nil {
+		device.volumeType = *mapping.VirtualName
+	}
+	if mapping.Ebs != nil {
+		device.snapshotId = firstValue(mapping.Ebs.SnapshotId)
+		device.size = fmt.Sprintf("%d", awssdk.Int64Value(mapping.Ebs.VolumeSize))
+		device.volumeType = awssdk.StringValue(mapping.Ebs.VolumeType)
+		device.encrypted = fmt.Sprintf("%t", awssdk.BoolValue(mapping.Ebs.Encrypted))
+		device.deleteOnTermination = fmt.Sprintf("%t", awssdk.BoolValue(mapping.Ebs.DeleteOnTermination))
+	}
+	return device
+}
+
+func newElasticIpSummary(address *ec2.Address) *elasticIpSummary {
+	return &elasticIpSummary{
+		allocationId:       firstValue(address.AllocationId),
+		name:               nameTag(address.Tags),
+		publicIp:           address.PublicIp,
+		privateIp:          firstValue(address.PrivateIpAddress),
+		associationId:      firstValue(address.AssociationId),
+		instanceId:         firstValue(address.InstanceId),
+		networkInterfaceId: firstValue(address.NetworkInterfaceId),
+		domain:             firstValue(address.Domain),
+	}
+}
+
+// isAssociated EC2-Classic addresses have no association id, only instance id
+func (o *elasticIpSummary) isAssociated() bool {
+	return o.associationId != NO_VALUE || o.instanceId != NO_VALUE
+}
+
+func newNetworkInterfaceSummary(eni *ec2.NetworkInterface) *networkInterfaceSummary {
+	summary := &networkInterfaceSummary{
+		id:               eni.NetworkInterfaceId,
+		name:             nameTag(eni.TagSet),
+		interfaceType:    firstValue(eni.InterfaceType),
+		status:           eni.Status,
+		instanceId:       NO_VALUE,
+		instanceOwnerId:  NO_VALUE,
+		privateIp:        firstValue(eni.PrivateIpAddress),
+		publicIp:         NO_VALUE,
+		vpcId:            firstValue(eni.VpcId),
+		subnetId:         firstValue(eni.SubnetId),
+		requesterManaged: awssdk.BoolValue(eni.RequesterManaged),
+		requesterId:      firstValue(eni.RequesterId),
+		description:      firstValue(eni.Description),
+	}
+	if eni.Attachment != nil {
+		summary.instanceId = firstValue(eni.Attachment.InstanceId)
+		summary.instanceOwnerId = firstValue(eni.Attachment.InstanceOwnerId)
+	}
+	if eni.Association != nil {
+		summary.publicIp = firstValue(eni.Association.PublicIp)
+	}
+	for _, group := range eni.Groups {
+		summary.securityGroups = append(summary.securityGroups, fmt.Sprintf("%s(%s)", awssdk.StringValue(group.GroupId), awssdk.StringValue(group.GroupName)))
+	}
+	return summary
+}
+
+// newInstanceStatus status can be nil for instance which isn't running, uptime is since last start
+func newInstanceStatus(instance *ec2.Instance, status *ec2.InstanceStatus, tz *ctltime.Timezone, now time.Time) *instanceStatus {
+	o := &instanceStatus{
+		systemStatus:   NO_VALUE,
+		instanceStatus: NO_VALUE,
+		uptime:         NO_VALUE,
+	}
+	if *instance.State.Name == ec2.InstanceStateNameRunning && instance.LaunchTime != nil {
+		o.uptime = ctltime.FormatAge(now.Sub(*instance.LaunchTime))
+	}
+	if status == nil {
+		return o
+	}
+	if status.SystemStatus != nil {
+		o.systemStatus = awssdk.StringValue(status.SystemStatus.Status)
+	}
+	if status.InstanceStatus != nil {
+		o.instanceStatus = awssdk.StringValue(status.InstanceStatus.Status)
+	}
+	for _, event := range status.Events {
+		description := awssdk.StringValue(event.Description)
+		// completed & canceled events are kept for a while, they need no action
+		if strings.HasPrefix(description, "[Completed]") || strings.HasPrefix(description, "[Canceled]") {
+			continue
+		}
+		// event is scheduled between NotBefore & NotAfter, it can be rescheduled until NotBeforeDeadline
+		schedule := []string{}
+		if event.NotBefore != nil {
+			schedule = append(schedule, fmt.Sprintf("not before %s", ctltime.Format(*tz.AdaptTimezone(event.NotBefore))))
+		}
+		if event.NotAfter != nil {
+			schedule = append(schedule, fmt.Sprintf("not after %s", ctltime.Format(*tz.AdaptTimezone(event.NotAfter))))
+		}
+		if event.NotBeforeDeadline != nil {
+			schedule = append(schedule, fmt.Sprintf("reschedule deadline %s", ctltime.Format(*tz.AdaptTimezone(event.NotBeforeDeadline))))
+		}
+		eventSummary := fmt.Sprintf("%s: %s", awssdk.StringValue(event.Code), description)
+		if len(schedule) != 0 {
+			eventSummary = fmt.Sprintf("%s (%s)", eventSummary, strings.Join(schedule, ", "))
+		}
+		o.events = append(o.events, eventSummary)
+	}
+	return o
+}
+
+func (o *instanceStatus) isImpaired() bool {
+	return o.systemStatus == ec2.SummaryStatusImpaired || o.instanceStatus == ec2.SummaryStatusImpaired
+}
+
+func (o *instanceStatus) formatEvents() string {
+	if len(o.events) == 0 {
+		return NO_VALUE
+	}
+	return strings.Join(o.events, "\n")
+}
+
+func newInstanceTypeSummary(info *ec2.InstanceTypeInfo) *instanceTypeSummary {
+	summary := &instanceTypeSummary{
+		typee:             awssdk.StringValue(info.InstanceType),
+		architectures:     NO_VALUE,
+		clockSpeed:        NO_VALUE,
+		gpuDetail:         NO_VALUE,
+		network:           NO_VALUE,
+		ebsOptimized:      NO_VALUE,
+		ebsBandwidth:      NO_VALUE,
+		instanceStorage:   NO_VALUE,
+		burstable:         awssdk.BoolValue(info.BurstablePerformanceSupported),
+		currentGeneration: awssdk.BoolValue(info.CurrentGeneration),
+		hypervisor:        firstValue(info.Hypervisor),
+	}
+	if info.VCpuInfo != nil {
+		summary.vcpus = awssdk.Int64Value(info.VCpuInfo.DefaultVCpus)
+		summary.cores = awssdk.Int64Value(info.VCpuInfo.DefaultCores)
+		summary.threadsPerCore = awssdk.Int64Value(info.VCpuInfo.DefaultThreadsPerCore)
+	}
+	if info.MemoryInfo != nil {
+		summary.memory = float64(awssdk.Int64Value(info.MemoryInfo.SizeInMiB)) / 1024
+	}
+	if info.ProcessorInfo != nil {
+		summary.architectures = strings.Join(awssdk.StringValueSlice(info.ProcessorInfo.SupportedArchitectures), ",")
+		if info.ProcessorInfo.SustainedClockSpeedInGhz != nil {
+			summary.clockSpeed = fmt.Sprintf("%.1f GHz", *info.ProcessorInfo.SustainedClockSpeedInGhz)
+		}
+	}
+	if info.GpuInfo != nil {
+		details := []string{}
+		for _, gpu := range info.GpuInfo.Gpus {
+			summary.gpus += awssdk.Int64Value(gpu.Count)
+			details = append(details, fmt.Sprintf("%dx %s %s", awssdk.Int64Value(gpu.Count), awssdk.StringValue(gpu.Manufacturer), awssdk.StringValue(gpu.Name)))
+		}
+		if len(details) != 0 {
+			summary.gpuDetail = strings.Join(details, ", ")
+		}
+	}
+	if info.NetworkInfo != nil {
+		summary.network = firstValue(info.NetworkInfo.NetworkPerformance)
+		summary.maxEnis = awssdk.Int64Value(info.NetworkInfo.MaximumNetworkInterfaces)
+	}
+	if info.EbsInfo != nil {
+		summary.ebsOptimized = firstValue(info.EbsInfo.EbsOptimizedSupport)
+		if info.EbsInfo.EbsOptimizedInfo != nil {
+			summary.ebsBandwidth = fmt.Sprintf("%d Mbps", awssdk.Int64Value(info.EbsInfo.EbsOptimizedInfo.BaselineBandwidthInMbps))
+		}
+	}
+	if info.InstanceStorageInfo != nil {
+		summary.instanceStorage = fmt.Sprintf("%d GB", awssdk.Int64Value(info.InstanceStorageInfo.TotalSizeInGB))
+	}
+	return summary
+}
+
+// formatMemory render memory in GiB without trailing zeros e.g. 0.5, 8
+func formatMemory(memory float64) string {
+	return strconv.FormatFloat(memory, 'f', -1, 64)
+}
+
+func newLaunchTemplateSummary(template *ec2.LaunchTemplate, tz *ctltime.Timezone) *launchTemplateSummary {
+	return &launchTemplateSummary{
+		id:             template.LaunchTemplateId,
+		name:           firstValue(template.LaunchTemplateName),
+		defaultVersion: awssdk.Int64Value(template.DefaultVersionNumber),
+		latestVersion:  awssdk.Int64Value(template.LatestVersionNumber),
+		createdBy:      firstValue(template.CreatedBy),
+		createdTime:    tz.AdaptTimezone(template.CreateTime),
+	}
+}
+
+func newLaunchTemplateDefinition(version *ec2.LaunchTemplateVersion, tz *ctltime.Timezone) *launchTemplateDefinition {
+	definition := &launchTemplateDefinition{
+		summary: &launchTemplateSummary{
+			id:   version.LaunchTemplateId,
+			name: firstValue(version.LaunchTemplateName),
+		},
+		version:            awssdk.Int64Value(version.VersionNumber),
+		versionDescription: firstValue(version.VersionDescription),
+		versionCreatedBy:   firstValue(version.CreatedBy),
+		versionCreatedTime: tz.AdaptTimezone(version.CreateTime),
+		imageId:            NO_VALUE,
+		instanceType:       NO_VALUE,
+		keyName:            NO_VALUE,
+		iamProfile:         NO_VALUE,
+		ebsOptimized:       NO_VALUE,
+		httpTokens:         NO_VALUE,
+		securityGroups:     map[string]string{},
+	}
+	data := version.LaunchTemplateData
+	if data == nil {
+		return definition
+	}
+	definition.imageId = firstValue(data.ImageId)
+	definition.instanceType = firstValue(data.InstanceType)
+	definition.keyName = firstValue(data.KeyName)
+	if data.IamInstanceProfile != nil {
+		definition.iamProfile = firstValue(data.IamInstanceProfile.Arn, data.IamInstanceProfile.Name)
+	}
+	if data.EbsOptimized != nil {
+		definition.ebsOptimized = strconv.FormatBool(*data.EbsOptimized)
+	}
+	if data.MetadataOptions != nil {
+		definition.httpTokens = firstValue(data.MetadataOptions.HttpTokens)
+	}
+	for _, id := range data.SecurityGroupIds {
+		definition.securityGroups[*id] = NO_VALUE
+	}
+	for _, mapping := range data.BlockDeviceMappings {
+		definition.blockDevices = append(definition.blockDevices, newLaunchTemplateBlockDevice(mapping))
+	}
+	for _, eni := range data.NetworkInterfaces {
+		for _, id := range eni.Groups {
+			definition.securityGroups[*id] = NO_VALUE
+		}
+		publicIp := NO_VALUE
+		if eni.AssociatePublicIpAddress != nil {
+			publicIp = strconv.FormatBool(*eni.AssociatePublicIpAddress)
+		}
+		securityGroups := NO_VALUE
+		if len(eni.Groups) != 0 {
+			securityGroups = strings.Join(awssdk.StringValueSlice(eni.Groups), "\n")
+		}
+		definition.networkInterfaces = append(definition.networkInterfaces, &launchTemplateNetworkInterface{
+			deviceIndex:    strconv.FormatInt(awssdk.Int64Value(eni.DeviceIndex), 10),
+			subnetId:       firstValue(eni.SubnetId),
+			publicIp:       publicIp,
+			securityGroups: securityGroups,
+		})
+	}
+	return definition
+}
+
+func newLaunchTemplateBlockDevice(mapping *ec2.LaunchTemplateBlockDeviceMapping) *launchTemplateBlockDevice {
+	device := &launchTemplateBlockDevice{
+		device:              awssdk.StringValue(mapping.DeviceName),
+		snapshotId:          NO_VALUE,
+		size:                NO_VALUE,
+		volumeType:          NO_VALUE,
+		iops:                NO_VALUE,
+		throughput:          NO_VALUE,
+		encrypted:           NO_VALUE,
+		deleteOnTermination: NO_VALUE,
+	}
+	// instance store volume has virtual name instead of ebs
+	if mapping.Ebs == nil {
+		device.volumeType = firstValue(mapping.VirtualName)
+		return device
+	}
+	ebs := mapping.Ebs
+	device.snapshotId = firstValue(ebs.SnapshotId)
+	device.volumeType = firstValue(ebs.VolumeType)
+	if ebs.VolumeSize != nil {
+		device.size = strconv.FormatInt(*ebs.VolumeSize, 10)
+	}
+	if ebs.Iops != nil {
+		device.iops = strconv.FormatInt(*ebs.Iops, 10)
+	}
+	if ebs.Throughput != nil {
+		device.throughput = strconv.FormatInt(*ebs.Throughput, 10)
+	}
+	if ebs.Encrypted != nil {
+		device.encrypted = strconv.FormatBool(*ebs.Encrypted)
+	}
+	if ebs.DeleteOnTermination != nil {
+		device.deleteOnTermination = strconv.FormatBool(*ebs.DeleteOnTermination)
+	}
+	return device
+}
+
+func newSpotRequestSummary(request *ec2.SpotInstanceRequest, tz *ctltime.Timezone) *spotRequestSummary {
+	summary := &spotRequestSummary{
+		id:           request.SpotInstanceRequestId,
+		state:        firstValue(request.State),
+		status:       NO_VALUE,
+		requestType:  firstValue(request.Type),
+		instanceType: NO_VALUE,
+		az:           firstValue(request.LaunchedAvailabilityZone),
+		maxPrice:     firstValue(request.SpotPrice),
+		instanceId:   firstValue(request.InstanceId),
+		createdTime:  tz.AdaptTimezone(request.CreateTime),
+	}
+	if request.Status != nil {
+		summary.status = firstValue(request.Status.Code)
+	}
+	if request.LaunchSpecification != nil {
+		summary.instanceType = firstValue(request.LaunchSpecification.InstanceType)
+		if summary.az == NO_VALUE && request.LaunchSpecification.Placement != nil {
+			summary.az = firstValue(request.LaunchSpecification.Placement.AvailabilityZone)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,315 @@
+package ec2
+
+import (
+	"cloudctl/provider/aws"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	VERDICT_ALLOW   = "ALLOW"
+	VERDICT_DENY    = "DENY"
+	VERDICT_SKIPPED = "SKIPPED"
+)
+
+var (
+	protocolNumbers = map[string]string{
+		"tcp":  "6",
+		"udp":  "17",
+		"icmp": "1",
+		"all":  "-1",
+	}
+	// network ACLs are stateless, return traffic to client ephemeral ports must be allowed as well
+	ephemeralPorts = portInterval{from: 1024, to: 65535}
+)
+
+// reachabilityEndpoint is source or destination of traffic, all data is fetched upfront so evaluation works offline
+type reachabilityEndpoint struct {
+	name string
+	// addresses of endpoint, primary address first
+	cidrs          []*net.IPNet
+	securityGroups []*ec2.SecurityGroup
+	subnetId       string
+	networkAcl     *ec2.NetworkAcl
+	// external endpoints (e.g. CIDR) aren't in a subnet, so no network ACL to evaluate
+	external bool
+}
+
+type portInterval struct {
+	from int64
+	to   int64
+}
+
+type reachabilityCheck struct {
+	step    string
+	verdict string
+	rule    string
+	reason  string
+}
+
+type reachabilityOutput struct {
+	from      string
+	to        string
+	protocol  string
+	port      int64
+	reachable bool
+	checks    []*reachabilityCheck
+	err       *aws.ErrorInfo
+}
+
+// Failure fails the reach command when traffic is blocked
+func (o *reachabilityOutput) Failure() error {
+	if o.err == nil && !o.reachable {
+		return NotReachable(o.from, o.to)
+	}
+	return nil
+}
+
+// evaluateReachability evaluate path from every source address to primary address of destination,
+// destination is reachable if any source address reaches it
+func evaluateReachability(source, destination *reachabilityEndpoint, protocol string, port int64) *reachabilityOutput {
+	output := &reachabilityOutput{from: source.name, to: destination.name, protocol: protocol, port: port}
+	protocolNumber := protocolNumbers[strings.ToLower(protocol)]
+	sourceAddresses := source.cidrs
+	if len(sourceAddresses) == 0 {
+		// security group source, only rules referencing the group can match
+		sourceAddresses = []*net.IPNet{nil}
+	}
+	for _, sourceAddress := range sourceAddresses {
+		checks, reachable := evaluatePath(source, sourceAddress, destination, protocolNumber, port)
+		if len(sourceAddresses) > 1 {
+			for _, check := range checks {
+				check.step = fmt.Sprintf("[%s] %s", sourceAddress.IP, check.step)
+			}
+		}
+		output.checks = append(output.checks, checks...)
+		output.reachable = output.reachable || reachable
+	}
+	return output
+}
+
+// evaluatePath evaluate source egress rules, network ACLs of both subnets in both directions and destination ingress rules
+func evaluatePath(source *reachabilityEndpoint, sourceAddress *net.IPNet, destination *reachabilityEndpoint, protocolNumber string, port int64) ([]*reachabilityCheck, bool) {
+	checks := []*reachabilityCheck{}
+	reachable := true
+	add := func(check *reachabilityCheck) {
+		checks = append(checks, check)
+		if check.verdict == VERDICT_DENY {
+			reachable = false
+		}
+	}
+	var destinationAddress *net.IPNet
+	if len(destination.cidrs) != 0 {
+		destinationAddress = destination.cidrs[0]
+	}
+	requestPorts := portInterval{from: port, to: port}
+
+	if len(source.securityGroups) == 0 {
+		add(&reachabilityCheck{step: "source security group egress", verdict: VERDICT_SKIPPED, rule: NO_VALUE, reason: "source has no security group"})
+	} else {
+		add(evaluateSecurityGroups("source security group egress", source.securityGroups, true, destination.securityGroups, destinationAddress, protocolNumber, port))
+	}
+
+	if len(source.subnetId) != 0 && source.subnetId == destination.subnetId {
+		add(&reachabilityCheck{step: "network ACL", verdict: VERDICT_SKIPPED, rule: NO_VALUE, reason: "source and destination are in the same subnet"})
+	} else {
+		add(evaluateEndpointNetworkAcl("source network ACL outbound", "source", source, true, destinationAddress, protocolNumber, requestPorts))
+		add(evaluateEndpointNetworkAcl("destination network ACL inbound", "destination", destination, false, sourceAddress, protocolNumber, requestPorts))
+		add(evaluateEndpointNetworkAcl("destination network ACL outbound (return)", "destination", destination, true, sourceAddress, protocolNumber, ephemeralPorts))
+		add(evaluateEndpointNetworkAcl("source network ACL inbound (return)", "source", source, false, destinationAddress, protocolNumber, ephemeralPorts))
+	}
+
+	add(evaluateSecurityGroups("destination security group ingress", destination.securityGroups, false, source.securityGroups, sourceAddress, protocolNumber, port))
+	return checks, reachable
+}
+
+// evaluateEndpointNetworkAcl skip evaluation when network ACL of endpoint or peer address is unknown
+func evaluateEndpointNetworkAcl(step, role string, endpoint *reachabilityEndpoint, egress bool, peer *net.IPNet, protocolNumber string, ports portInterval) *reachabilityCheck {
+	if endpoint.external {
+		return &reachabilityCheck{step: step, verdict: VERDICT_SKIPPED, rule: NO_VALUE, reason: fmt.Sprintf("%s is a CIDR", role)}
+	}
+	if endpoint.networkAcl == nil {
+		return &reachabilityCheck{step: step, verdict: VERDICT_SKIPPED, rule: NO_VALUE, reason: fmt.Sprintf("%s subnet is unknown", role)}
+	}
+	if peer == nil {
+		return &reachabilityCheck{step: step, verdict: VERDICT_SKIPPED, rule: NO_VALUE, reason: "peer address is unknown"}
+	}
+	return evaluateNetworkAcl(step, endpoint.networkAcl, egress, peer, protocolNumber, ports)
+}
+
+// evaluateSecurityGroups security groups are allow-only, traffic is allowed if any rule matches the peer
+func evaluateSecurityGroups(step string, securityGroups []*ec2.SecurityGroup, egress bool, peerGroups []*ec2.SecurityGroup, peerAddress *net.IPNet, protocolNumber string, port int64) *reachabilityCheck {
+	peerGroupIds := map[string]bool{}
+	for _, sg := range peerGroups {
+		peerGroupIds[*sg.GroupId] = true
+	}
+	for _, sg := range securityGroups {
+		permissions := sg.IpPermissions
+		if egress {
+			permissions = sg.IpPermissionsEgress
+		}
+		for _, permission := range permissions {
+			if !permissionMatches(permission, protocolNumber, port) {
+				continue
+			}
+			portRange, protocol := permissionPortRange(permission)
+			rule := fmt.Sprintf("%s(%s) %s %s", *sg.GroupId, *sg.GroupName, protocol, portRange)
+			for _, pair := range permission.UserIdGroupPairs {
+				if peerGroupIds[*pair.GroupId] {
+					return &reachabilityCheck{step: step, verdict: VERDICT_ALLOW, rule: fmt.Sprintf("%s %s", rule, *pair.GroupId), reason: "peer security group is referenced"}
+				}
+			}
+			for _, cidr := range permissionCidrs(permission) {
+				if cidrContains(cidr, peerAddress) {
+					return &reachabilityCheck{step: step, verdict: VERDICT_ALLOW, rule: fmt.Sprintf("%s %s", rule, cidr), reason: "peer address is in rule CIDR"}
+				}
+			}
+		}
+	}
+	return &reachabilityCheck{step: step, verdict: VERDICT_DENY, rule: NO_VALUE, reason: "no rule matches protocol, port and peer"}
+}
+
+// evaluateNetworkAcl entries are evaluated by rule number, first entry matching a port decides for it,
+// so traffic is allowed only if every port of interval is allowed before any deny
+func evaluateNetworkAcl(step string, acl *ec2.NetworkAcl, egress bool, peer *net.IPNet, protocolNumber string, ports portInterval) *reachabilityCheck {
+	entries := []*ec2.NetworkAclEntry{}
+	for _, entry := range acl.Entries {
+		if *entry.Egress == egress {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return *entries[i].RuleNumber < *entries[j].RuleNumber
+	})
+	remaining := []portInterval{ports}
+	allowedBy := []string{}
+	for _, entry := range entries {
+		if *entry.Protocol != "-1" && protocolNumber != "-1" && *entry.Protocol != protocolNumber {
+			continue
+		}
+		cidr := ""
+		if entry.CidrBlock != nil {
+			cidr = *entry.CidrBlock
+		} else if entry.Ipv6CidrBlock != nil {
+			cidr = *entry.Ipv6CidrBlock
+		}
+		if !cidrContains(cidr, peer) {
+			continue
+		}
+		matched, rest := remaining, []portInterval{}
+		// icmp type/code are not evaluated
+		if *entry.Protocol != "-1" && protocolNumber != "1" && entry.PortRange != nil {
+			matched, rest = splitPortIntervals(remaining, portInterval{from: *entry.PortRange.From, to: *entry.PortRange.To})
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		ruleNumber := fmt.Sprintf("%d", *entry.RuleNumber)
+		if *entry.RuleNumber == 32767 {
+			ruleNumber = "*"
+		}
+		rule := fmt.Sprintf("%s rule %s %s", *acl.NetworkAclId, ruleNumber, cidr)
+		if *entry.RuleAction != ec2.RuleActionAllow {
+			return &reachabilityCheck{step: step, verdict: VERDICT_DENY, rule: rule, reason: fmt.Sprintf("first matching entry denies ports %s", formatPortIntervals(matched))}
+		}
+		allowedBy = append(allowedBy, rule)
+		if remaining = rest; len(remaining) == 0 {
+			return &reachabilityCheck{step: step, verdict: VERDICT_ALLOW, rule: strings.Join(allowedBy, ", "), reason: "first matching entry allows"}
+		}
+	}
+	rule := NO_VALUE
+	if len(allowedBy) != 0 {
+		rule = strings.Join(allowedBy, ", ")
+	}
+	return &reachabilityCheck{step: step, verdict: VERDICT_DENY, rule: rule, reason: fmt.Sprintf("no entry matches ports %s", formatPortIntervals(remaining))}
+}
+
+// splitPortIntervals split intervals into parts within and outside of r
+func splitPortIntervals(intervals []portInterval, r portInterval) ([]portInterval, []portInterval) {
+	within, outside := []portInterval{}, []portInterval{}
+	for _, interval := range intervals {
+		if interval.to < r.from || interval.from > r.to {
+			outside = append(outside, interval)
+			continue
+		}
+		if interval.from < r.from {
+			outside = append(outside, portInterval{from: interval.from, to: r.from - 1})
+		}
+		if interval.to > r.to {
+			outside = append(outside, portInterval{from: r.to + 1, to: interval.to})
+		}
+		part := interval
+		if part.from < r.from {
+			part.from = r.from
+		}
+		if part.to > r.to {
+			part.to = r.to
+		}
+		within = append(within, part)
+	}
+	return within, outside
+}
+
+func formatPortIntervals(intervals []portInterval) string {
+	formatted := []string{}
+	for _, interval := range intervals {
+		if interval.from == interval.to {
+			formatted = append(formatted, fmt.Sprintf("%d", interval.from))
+		} else {
+			formatted = append(formatted, fmt.Sprintf("%d-%d", interval.from, interval.to))
+		}
+	}
+	return strings.Join(formatted, ",")
+}
+
+func permissionMatches(permission *ec2.IpPermission, protocolNumber string, port int64) bool {
+	protocol := strings.ToLower(*permission.IpProtocol)
+	if number, ok := protocolNumbers[protocol]; ok {
+		protocol = number
+	}
+	if protocol == "-1" {
+		return true
+	}
+	if protocolNumber != "-1" && protocol != protocolNumber {
+		return false
+	}
+	// icmp type/code are not evaluated
+	if protocolNumber == "1" || permission.FromPort == nil || permission.ToPort == nil {
+		return true
+	}
+	return *permission.FromPort <= port && port <= *permission.ToPort
+}
+
+// cidrContains return true if cidr contains whole peer network
+func cidrContains(cidr string, peer *net.IPNet) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil || peer == nil {
+		return false
+	}
+	networkOnes, networkBits := network.Mask.Size()
+	peerOnes, peerBits := peer.Mask.Size()
+	return networkBits == peerBits && peerOnes >= networkOnes && network.Contains(peer.IP)
+}
+
+// parseEndpointCidr parse CIDR or a single IP address
+func parseEndpointCidr(value string) (*net.IPNet, error) {
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address %s", value)
+		}
+		if ip.To4() != nil {
+			return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %s", value)
+	}
+	return network, nil
+}
@@ -0,0 +1,253 @@
+package ec2
+
+import (
+	"net"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func mustCidr(t *testing.T, value string) *net.IPNet {
+	t.Helper()
+	cidr, err := parseEndpointCidr(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cidr
+}
+
+func aclEntry(ruleNumber int64, egress bool, protocol, cidr string, from, to int64, action string) *ec2.NetworkAclEntry {
+	entry := &ec2.NetworkAclEntry{
+		RuleNumber: aws.Int64(ruleNumber),
+		Egress:     aws.Bool(egress),
+		Protocol:   aws.String(protocol),
+		CidrBlock:  aws.String(cidr),
+		RuleAction: aws.String(action),
+	}
+	if protocol != "-1" {
+		entry.PortRange = &ec2.PortRange{From: aws.Int64(from), To: aws.Int64(to)}
+	}
+	return entry
+}
+
+func networkAcl(entries ...*ec2.NetworkAclEntry) *ec2.NetworkAcl {
+	// default entries deny everything not matched before
+	entries = append(entries,
+		aclEntry(32767, false, "-1", "0.0.0.0/0", 0, 0, ec2.RuleActionDeny),
+		aclEntry(32767, true, "-1", "0.0.0.0/0", 0, 0, ec2.RuleActionDeny),
+	)
+	return &ec2.NetworkAcl{NetworkAclId: aws.String("acl-1"), Entries: entries}
+}
+
+func securityGroup(id string, ingress, egress []*ec2.IpPermission) *ec2.SecurityGroup {
+	return &ec2.SecurityGroup{GroupId: aws.String(id), GroupName: aws.String(id), IpPermissions: ingress, IpPermissionsEgress: egress}
+}
+
+func tcpPermission(from, to int64, cidr string) *ec2.IpPermission {
+	return &ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(from),
+		ToPort:     aws.Int64(to),
+		IpRanges:   []*ec2.IpRange{{CidrIp: aws.String(cidr)}},
+	}
+}
+
+var allEgress = []*ec2.IpPermission{{IpProtocol: aws.String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}}}
+
+func TestCidrContains(t *testing.T) {
+	tests := []struct {
+		cidr string
+		peer string
+		want bool
+	}{
+		{"10.0.0.0/16", "10.0.1.5", true},
+		{"10.0.0.0/16", "10.0.1.0/24", true},
+		{"10.0.1.0/24", "10.0.0.0/16", false},
+		{"10.0.0.0/16", "10.1.0.5", false},
+		{"0.0.0.0/0", "2001:db8::1", false},
+		{"::/0", "2001:db8::1", true},
+		{"invalid", "10.0.0.1", false},
+	}
+	for _, test := range tests {
+		if got := cidrContains(test.cidr, mustCidr(t, test.peer)); got != test.want {
+			t.Errorf("cidrContains(%s, %s) = %v, want %v", test.cidr, test.peer, got, test.want)
+		}
+	}
+	if cidrContains("0.0.0.0/0", nil) {
+		t.Errorf("cidrContains with unknown peer must be false")
+	}
+}
+
+func TestEvaluateNetworkAcl(t *testing.T) {
+	peer := "10.1.0.5"
+	tests := []struct {
+		name    string
+		acl     *ec2.NetworkAcl
+		egress  bool
+		ports   portInterval
+		verdict string
+	}{
+		{
+			name:    "allowed port",
+			acl:     networkAcl(aclEntry(100, false, "6", "10.1.0.0/16", 22, 22, ec2.RuleActionAllow)),
+			ports:   portInterval{from: 22, to: 22},
+			verdict: VERDICT_ALLOW,
+		},
+		{
+			name: "first matching entry denies",
+			acl: networkAcl(
+				aclEntry(90, false, "6", "10.1.0.5/32", 22, 22, ec2.RuleActionDeny),
+				aclEntry(100, false, "6", "10.1.0.0/16", 22, 22, ec2.RuleActionAllow),
+			),
+			ports:   portInterval{from: 22, to: 22},
+			verdict: VERDICT_DENY,
+		},
+		{
+			name:    "other direction isn't evaluated",
+			acl:     networkAcl(aclEntry(100, true, "6", "10.1.0.0/16", 22, 22, ec2.RuleActionAllow)),
+			ports:   portInterval{from: 22, to: 22},
+			verdict: VERDICT_DENY,
+		},
+		{
+			name:    "peer outside of entry cidr",
+			acl:     networkAcl(aclEntry(100, false, "6", "10.2.0.0/16", 22, 22, ec2.RuleActionAllow)),
+			ports:   portInterval{from: 22, to: 22},
+			verdict: VERDICT_DENY,
+		},
+		{
+			name:    "ephemeral ports allowed",
+			acl:     networkAcl(aclEntry(100, true, "6", "0.0.0.0/0", 1024, 65535, ec2.RuleActionAllow)),
+			egress:  true,
+			ports:   ephemeralPorts,
+			verdict: VERDICT_ALLOW,
+		},
+		{
+			name: "ephemeral ports allowed by several entries",
+			acl: networkAcl(
+				aclEntry(100, true, "6", "0.0.0.0/0", 1024, 32767, ec2.RuleActionAllow),
+				aclEntry(110, true, "-1", "10.1.0.0/16", 0, 0, ec2.RuleActionAllow),
+			),
+			egress:  true,
+			ports:   ephemeralPorts,
+			verdict: VERDICT_ALLOW,
+		},
+		{
+			name:    "ephemeral ports partially allowed",
+			acl:     networkAcl(aclEntry(100, true, "6", "0.0.0.0/0", 32768, 65535, ec2.RuleActionAllow)),
+			egress:  true,
+			ports:   ephemeralPorts,
+			verdict: VERDICT_DENY,
+		},
+		{
+			name: "ephemeral ports partially denied",
+			acl: networkAcl(
+				aclEntry(90, true, "6", "0.0.0.0/0", 3306, 3306, ec2.RuleActionDeny),
+				aclEntry(100, true, "-1", "0.0.0.0/0", 0, 0, ec2.RuleActionAllow),
+			),
+			egress:  true,
+			ports:   ephemeralPorts,
+			verdict: VERDICT_DENY,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := evaluateNetworkAcl("acl", test.acl, test.egress, mustCidr(t, peer), "6", test.ports)
+			if check.verdict != test.verdict {
+				t.Errorf("verdict = %s (%s %s), want %s", check.verdict, check.rule, check.reason, test.verdict)
+			}
+		})
+	}
+}
+
+func TestEvaluateReachability(t *testing.T) {
+	openAcl := networkAcl(
+		aclEntry(100, false, "-1", "0.0.0.0/0", 0, 0, ec2.RuleActionAllow),
+		aclEntry(100, true, "-1", "0.0.0.0/0", 0, 0, ec2.RuleActionAllow),
+	)
+	// ssh is allowed inbound but return traffic to ephemeral ports isn't
+	noReturnAcl := networkAcl(
+		aclEntry(100, false, "6", "0.0.0.0/0", 22, 22, ec2.RuleActionAllow),
+		aclEntry(100, true, "6", "0.0.0.0/0", 443, 443, ec2.RuleActionAllow),
+	)
+	destination := func(acl *ec2.NetworkAcl, ingress ...*ec2.IpPermission) *reachabilityEndpoint {
+		return &reachabilityEndpoint{
+			name:           "i-destination",
+			cidrs:          []*net.IPNet{mustCidr(t, "10.0.2.10")},
+			securityGroups: []*ec2.SecurityGroup{securityGroup("sg-destination", ingress, allEgress)},
+			subnetId:       "subnet-destination",
+			networkAcl:     acl,
+		}
+	}
+	instanceSource := func(ips ...string) *reachabilityEndpoint {
+		source := &reachabilityEndpoint{
+			name:           "i-source",
+			securityGroups: []*ec2.SecurityGroup{securityGroup("sg-source", nil, allEgress)},
+			subnetId:       "subnet-source",
+			networkAcl:     openAcl,
+		}
+		for _, ip := range ips {
+			source.cidrs = append(source.cidrs, mustCidr(t, ip))
+		}
+		return source
+	}
+	tests := []struct {
+		name        string
+		source      *reachabilityEndpoint
+		destination *reachabilityEndpoint
+		reachable   bool
+	}{
+		{
+			name:        "allowed by cidr",
+			source:      instanceSource("10.0.1.10"),
+			destination: destination(openAcl, tcpPermission(22, 22, "10.0.1.0/24")),
+			reachable:   true,
+		},
+		{
+			name:        "port isn't allowed",
+			source:      instanceSource("10.0.1.10"),
+			destination: destination(openAcl, tcpPermission(443, 443, "10.0.1.0/24")),
+			reachable:   false,
+		},
+		{
+			name:   "allowed by security group reference",
+			source: instanceSource("10.0.1.10"),
+			destination: destination(openAcl, &ec2.IpPermission{
+				IpProtocol:       aws.String("tcp"),
+				FromPort:         aws.Int64(22),
+				ToPort:           aws.Int64(22),
+				UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-source")}},
+			}),
+			reachable: true,
+		},
+		{
+			name:        "return traffic denied by destination network ACL",
+			source:      instanceSource("10.0.1.10"),
+			destination: destination(noReturnAcl, tcpPermission(22, 22, "10.0.1.0/24")),
+			reachable:   false,
+		},
+		{
+			name:        "one of source addresses is allowed",
+			source:      instanceSource("10.0.1.10", "10.0.9.10"),
+			destination: destination(openAcl, tcpPermission(22, 22, "10.0.9.10/32")),
+			reachable:   true,
+		},
+		{
+			name:        "external cidr must be fully allowed",
+			source:      &reachabilityEndpoint{name: "10.0.0.0/16", cidrs: []*net.IPNet{mustCidr(t, "10.0.0.0/16")}, external: true},
+			destination: destination(openAcl, tcpPermission(22, 22, "10.0.1.0/24")),
+			reachable:   false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := evaluateReachability(test.source, test.destination, "tcp", 22)
+			if output.reachable != test.reachable {
+				for _, check := range output.checks {
+					t.Logf("%s: %s %s %s", check.step, check.verdict, check.rule, check.reason)
+				}
+				t.Errorf("reachable = %v, want %v", output.reachable, test.reachable)
+			}
+		})
+	}
+}
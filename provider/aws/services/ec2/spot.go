@@ -0,0 +1,161 @@
+package ec2
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	LIFECYCLE_ON_DEMAND = "on-demand"
+
+	DEFAULT_SPOT_PRODUCT = "Linux/UNIX"
+	SPARKLINE_WIDTH      = 40
+	// price history period when --since isn't set
+	DEFAULT_SPOT_PRICE_PERIOD = 7 * 24 * time.Hour
+)
+
+var (
+	// sparkline levels from lowest to highest price
+	sparklineLevels = []byte("_.-~^")
+)
+
+// instanceLifecycle on-demand instances have no lifecycle attribute
+func instanceLifecycle(instance *ec2.Instance) string {
+	if instance.InstanceLifecycle == nil {
+		return LIFECYCLE_ON_DEMAND
+	}
+	return *instance.InstanceLifecycle
+}
+
+type spotPricePoint struct {
+	timestamp time.Time
+	price     float64
+}
+
+// newSpotPriceSummaries group price history by az, instance type & product, prices are step functions over [start, end]
+func newSpotPriceSummaries(prices []*ec2.SpotPrice, start, end time.Time) []*spotPriceSummary {
+	pointsByKey := map[[3]string][]*spotPricePoint{}
+	for _, price := range prices {
+		value, err := strconv.ParseFloat(awssdk.StringValue(price.SpotPrice), 64)
+		if err != nil || price.Timestamp == nil {
+			continue
+		}
+		key := [3]string{awssdk.StringValue(price.AvailabilityZone), awssdk.StringValue(price.InstanceType), awssdk.StringValue(price.ProductDescription)}
+		pointsByKey[key] = append(pointsByKey[key], &spotPricePoint{timestamp: *price.Timestamp, price: value})
+	}
+	summaries := []*spotPriceSummary{}
+	for key, points := range pointsByKey {
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].timestamp.Before(points[j].timestamp)
+		})
+		// first point may be before start, it is the price in effect at start
+		initial := priceAt(points, start)
+		summary := &spotPriceSummary{
+			az:        key[0],
+			typee:     key[1],
+			product:   key[2],
+			current:   points[len(points)-1].price,
+			min:       initial,
+			max:       initial,
+			sparkline: sparkline(resamplePrices(points, start, end, SPARKLINE_WIDTH)),
+		}
+		for _, point := range points {
+			if !point.timestamp.After(start) {
+				continue
+			}
+			summary.changes++
+			if point.price < summary.min {
+				summary.min = point.price
+			}
+			if point.price > summary.max {
+				summary.max = point.price
+			}
+		}
+		summary.average = averagePrice(points, start, end)
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].typee != summaries[j].typee {
+			return summaries[i].typee < summaries[j].typee
+		}
+		if summaries[i].az != summaries[j].az {
+			return summaries[i].az < summaries[j].az
+		}
+		return summaries[i].product < summaries[j].product
+	})
+	return summaries
+}
+
+// priceAt return price in effect at t, points must be sorted by time
+func priceAt(points []*spotPricePoint, t time.Time) float64 {
+	price := points[0].price
+	for _, point := range points {
+		if point.timestamp.After(t) {
+			break
+		}
+		price = point.price
+	}
+	return price
+}
+
+// resamplePrices sample price in effect at n evenly spaced times between start and end
+func resamplePrices(points []*spotPricePoint, start, end time.Time, n int) []float64 {
+	samples := make([]float64, n)
+	step := end.Sub(start) / time.Duration(n)
+	for i := range samples {
+		samples[i] = priceAt(points, start.Add(step*time.Duration(i)))
+	}
+	return samples
+}
+
+// averagePrice time weighted average price between start and end
+func averagePrice(points []*spotPricePoint, start, end time.Time) float64 {
+	if !end.After(start) {
+		return points[len(points)-1].price
+	}
+	total := 0.0
+	from := start
+	price := priceAt(points, start)
+	for _, point := range points {
+		if !point.timestamp.After(start) {
+			continue
+		}
+		if point.timestamp.After(end) {
+			break
+		}
+		total += price * point.timestamp.Sub(from).Seconds()
+		from, price = point.timestamp, point.price
+	}
+	total += price * end.Sub(from).Seconds()
+	return total / end.Sub(start).Seconds()
+}
+
+// sparkline render values as ASCII characters, flat line if all values are equal
+func sparkline(values []float64) string {
+	if len(values) == 0 {
+		return NO_VALUE
+	}
+	min, max := values[0], values[0]
+	for _, value := range values {
+		if value < min {
+			min = value
+		}
+		if value > max {
+			max = value
+		}
+	}
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if max > min {
+			level = int((value-min)/(max-min)*float64(len(sparklineLevels)-1) + 0.5)
+		}
+		line.WriteByte(sparklineLevels[level])
+	}
+	return line.String()
+}
@@ -0,0 +1,120 @@
+package ec2
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	// content type of single part user data, detected by its first line like cloud-init does
+	userDataContentTypes = []struct {
+		prefix      string
+		contentType string
+	}{
+		{"#!", "text/x-shellscript"},
+		{"#cloud-config", "text/cloud-config"},
+		{"#include", "text/x-include-url"},
+		{"#cloud-boothook", "text/cloud-boothook"},
+		{"#part-handler", "text/part-handler"},
+		{"<powershell>", "text/x-powershell"},
+		{"<script>", "text/x-cmd"},
+	}
+)
+
+type userDataPart struct {
+	contentType string
+	filename    string
+	compressed  bool
+	content     string
+}
+
+// decodeUserData decode user data attribute, gzip and multipart MIME content is expanded into parts
+func decodeUserData(encoded string) ([]*userDataPart, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("user data isn't base64 encoded: %w", err)
+	}
+	data, compressed, err := gunzipIfCompressed(data)
+	if err != nil {
+		return nil, err
+	}
+	if message, err := mail.ReadMessage(bufio.NewReader(bytes.NewReader(data))); err == nil {
+		mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+		if err == nil && strings.HasPrefix(mediaType, "multipart/") {
+			parts, err := decodeMultipartUserData(message.Body, params["boundary"])
+			for _, part := range parts {
+				part.compressed = part.compressed || compressed
+			}
+			return parts, err
+		}
+	}
+	return []*userDataPart{{contentType: detectUserDataContentType(data), filename: NO_VALUE, compressed: compressed, content: string(data)}}, nil
+}
+
+func decodeMultipartUserData(body io.Reader, boundary string) ([]*userDataPart, error) {
+	parts := []*userDataPart{}
+	reader := multipart.NewReader(body, boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart user data: %w", err)
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+			if content, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(content)), "")); err != nil {
+				return nil, fmt.Errorf("invalid base64 part in user data: %w", err)
+			}
+		}
+		content, compressed, err := gunzipIfCompressed(content)
+		if err != nil {
+			return nil, err
+		}
+		contentType := part.Header.Get("Content-Type")
+		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+			contentType = mediaType
+		}
+		filename := part.FileName()
+		if len(filename) == 0 {
+			filename = NO_VALUE
+		}
+		parts = append(parts, &userDataPart{contentType: contentType, filename: filename, compressed: compressed, content: string(content)})
+	}
+}
+
+func gunzipIfCompressed(data []byte) ([]byte, bool, error) {
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return data, false, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid gzip user data: %w", err)
+	}
+	defer reader.Close()
+	data, err = io.ReadAll(reader)
+	return data, true, err
+}
+
+func detectUserDataContentType(data []byte) string {
+	content := strings.TrimSpace(string(data))
+	for _, candidate := range userDataContentTypes {
+		if strings.HasPrefix(content, candidate.prefix) {
+			return candidate.contentType
+		}
+	}
+	return "text/plain"
+}
@@ -1,8 +1,11 @@
 package ec2
 
 import (
+	ctltime "cloudctl/time"
 	"cloudctl/viewer"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -15,7 +18,30 @@ var (
 		"PrivateIp",
 		"vpc",
 		"subnet",
+		"Asg",
+		"Lifecycle",
 		"LaunchAt",
+		"Tags",
+	}
+	// columns hidden unless --wide
+	instanceSummaryWideColumns        = []string{"PublicIpDNS", "PrivateIpDNS", "IAMRoleARN"}
+	instanceNetworkSummaryWideColumns = []string{"description", "privateIpv4DNS", "publicIpv4DNS", "attachTime", "vpcId", "deleteOnTermination"}
+	networkInterfaceListWideColumns   = []string{"InstanceOwner", "Vpc", "RequesterManaged", "Description"}
+	instanceListWideColumns           = []string{"Lifecycle", "Tags"}
+	securityGroupListWideColumns      = []string{"Description", "Tags"}
+	subnetListWideColumns             = []string{"PublicIpOnLaunch", "RouteTable"}
+	volumeListWideColumns             = []string{"Iops", "Encrypted", "CreateTime"}
+	snapshotListWideColumns           = []string{"Progress", "Encrypted", "Description"}
+	imageListWideColumns              = []string{"Architecture", "RootDevice", "Snapshots"}
+	elasticIpListWideColumns          = []string{"AllocationId", "AssociationId", "Domain"}
+	instanceTypeListWideColumns       = []string{"Network", "EbsOptimized", "InstanceStorage"}
+	launchTemplateListWideColumns     = []string{"CreatedBy"}
+	spotRequestListWideColumns        = []string{"Status", "CreatedAt"}
+	instanceStatusTableHeader         = viewer.Row{
+		"SystemCheck",
+		"InstanceCheck",
+		"Uptime",
+		"Events",
 	}
 	instanceSummaryTableHeader = viewer.Row{
 		"Id",
@@ -36,6 +62,8 @@ var (
 		"Monitoring",
 		"OSType",
 		"LaunchTime",
+		"vCPU",
+		"Memory(GiB)",
 	}
 	instanceSecurityGroupInboundSummaryTableHeader = viewer.Row{
 		"PortRange",
@@ -63,6 +91,201 @@ var (
 		"KMS",
 		"DeleteOntermination",
 	}
+	securityGroupListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Vpc",
+		"Description",
+		"IngressRules",
+		"EgressRules",
+		"Tags",
+	}
+	securityGroupNetworkInterfaceTableHeader = viewer.Row{
+		"Id",
+		"Type",
+		"Status",
+		"PrivateIp",
+		"InstanceId",
+		"Description",
+	}
+	securityGroupInstanceTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"State",
+		"Type",
+	}
+	securityGroupReferenceTableHeader = viewer.Row{
+		"GroupId",
+		"Direction",
+		"PortRange",
+		"Protocol",
+	}
+	securityGroupFindingTableHeader = viewer.Row{
+		"Severity",
+		"Region",
+		"GroupId",
+		"Direction",
+		"PortRange",
+		"Protocol",
+		"Source",
+		"Finding",
+		"Exposed",
+	}
+	reachabilityCheckTableHeader = viewer.Row{
+		"Step",
+		"Verdict",
+		"Rule",
+		"Reason",
+	}
+	vpcListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Cidr",
+		"State",
+		"Default",
+		"Subnets",
+		"Instances",
+	}
+	subnetListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Vpc",
+		"Az",
+		"Cidr",
+		"AvailableIps",
+		"PublicIpOnLaunch",
+		"RouteTable",
+		"Instances",
+	}
+	routeTableTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Main",
+		"Associations",
+		"Destination",
+		"Target",
+		"State",
+	}
+	vpcGatewayTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Type",
+		"State",
+		"Subnet",
+		"PublicIp",
+		"PrivateIp",
+	}
+	vpcEndpointTableHeader = viewer.Row{
+		"Id",
+		"ServiceName",
+		"Type",
+		"State",
+	}
+	vpcPeeringConnectionTableHeader = viewer.Row{
+		"Id",
+		"Requester",
+		"Accepter",
+		"Status",
+	}
+	volumeListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Type",
+		"Size(GiB)",
+		"Iops",
+		"State",
+		"Az",
+		"Encrypted",
+		"AttachedTo",
+		"CreateTime",
+	}
+	snapshotListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"VolumeId",
+		"Size(GiB)",
+		"State",
+		"Progress",
+		"Encrypted",
+		"StartTime",
+		"Age",
+		"Description",
+	}
+	cleanupTotalTableHeader = viewer.Row{
+		"Resource",
+		"Count",
+		"Size(GiB)",
+	}
+	resourceDeletionTableHeader = viewer.Row{
+		"Id",
+		"Status",
+		"Error",
+	}
+	imageListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"CreationDate",
+		"Age",
+		"Architecture",
+		"RootDevice",
+		"State",
+		"Public",
+		"Snapshots",
+		"Size(GiB)",
+	}
+	imageBlockDeviceTableHeader = viewer.Row{
+		"Device",
+		"SnapshotId",
+		"SourceVolume",
+		"Size(GiB)",
+		"VolumeType",
+		"Encrypted",
+		"DeleteOnTermination",
+	}
+	elasticIpListTableHeader = viewer.Row{
+		"PublicIp",
+		"AllocationId",
+		"Name",
+		"AssociationId",
+		"InstanceId",
+		"NetworkInterface",
+		"PrivateIp",
+		"Domain",
+	}
+	networkInterfaceListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Type",
+		"Status",
+		"InstanceId",
+		"InstanceOwner",
+		"PrivateIp",
+		"PublicIp",
+		"Vpc",
+		"Subnet",
+		"SecurityGroups",
+		"RequesterManaged",
+		"Description",
+	}
+	connectTableHeader = viewer.Row{
+		"InstanceId",
+		"Name",
+		"Method",
+		"Target",
+		"Forward",
+		"Status",
+	}
+	instanceTypeListTableHeader = viewer.Row{
+		"Type",
+		"vCPU",
+		"Memory(GiB)",
+		"Architecture",
+		"GPU",
+		"Network",
+		"EbsOptimized",
+		"InstanceStorage",
+		"CurrentGeneration",
+	}
 	instanceNetworkSummaryTableHeader = viewer.Row{
 		"id",
 		"description",
@@ -77,6 +300,76 @@ var (
 		"deleteOnTermination",
 		"securityGroups",
 	}
+	spotRequestListTableHeader = viewer.Row{
+		"Id",
+		"State",
+		"Status",
+		"Type",
+		"InstanceType",
+		"Az",
+		"MaxPrice",
+		"InstanceId",
+		"CreatedAt",
+	}
+	spotPriceTableHeader = viewer.Row{
+		"Type",
+		"Az",
+		"Product",
+		"Current",
+		"Min",
+		"Max",
+		"Avg",
+		"Changes",
+		"Trend",
+	}
+	launchTemplateListTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"DefaultVersion",
+		"LatestVersion",
+		"CreatedBy",
+		"CreatedAt",
+	}
+	launchTemplateVersionTableHeader = viewer.Row{
+		"Id",
+		"Name",
+		"Version",
+		"DefaultVersion",
+		"LatestVersion",
+		"Description",
+		"CreatedBy",
+		"CreatedAt",
+	}
+	launchTemplateInstanceTableHeader = viewer.Row{
+		"AMI",
+		"Type",
+		"KeyPair",
+		"IamProfile",
+		"EbsOptimized",
+		"HttpTokens",
+	}
+	launchTemplateBlockDeviceTableHeader = viewer.Row{
+		"Device",
+		"SnapshotId",
+		"Size(GiB)",
+		"VolumeType",
+		"Iops",
+		"Throughput",
+		"Encrypted",
+		"DeleteOnTermination",
+	}
+	launchTemplateNetworkInterfaceTableHeader = viewer.Row{
+		"DeviceIndex",
+		"SubnetId",
+		"PublicIp",
+		"SecurityGroups",
+	}
+	launchTemplateDiffTableHeader = viewer.Row{
+		"Field",
+		"From",
+		"To",
+		"Change",
+	}
 )
 
 func instanceListViewer(o interface{}) viewer.Viewer {
@@ -89,22 +382,39 @@ func instanceListViewer(o interface{}) viewer.Viewer {
 		return erroViewer
 	}
 
+	if data.summary {
+		return instanceGroupSummaryViewer(data)
+	}
 	compoundViewer := viewer.NewCompoundViewer()
-	for state, instanceSummaries := range data.instancesByState {
+	for _, group := range data.groups {
 		tViewer := viewer.NewTableViewer()
-		tViewer.AddHeader(instanceListTableHeader)
-		tViewer.SetTitle(fmt.Sprintf("Instances[%s]", state))
-		for _, instance := range instanceSummaries {
-			tViewer.AddRow(viewer.Row{
-				*instance.id,
-				*instance.typee,
-				*instance.az,
-				*instance.publicIp,
-				*instance.privateIp,
-				*instance.vpcId,
-				*instance.subnetId,
-				*instance.launchTime,
-			})
+		header := instanceListTableHeader
+		if data.showStatus {
+			header = append(append(viewer.Row{}, instanceListTableHeader...), instanceStatusTableHeader...)
+		}
+		tViewer.AddHeader(header)
+		tViewer.SetWideColumns(instanceListWideColumns...)
+		tViewer.SetTitle(group.title())
+		for _, instance := range group.instances {
+			fields := viewer.Fields{
+				"Id":        *instance.id,
+				"Type":      *instance.typee,
+				"Az":        *instance.az,
+				"PublicIp":  *instance.publicIp,
+				"PrivateIp": *instance.privateIp,
+				"vpc":       *instance.vpcId,
+				"subnet":    *instance.subnetId,
+				"Asg":       instance.asg,
+				"Lifecycle": instance.lifecycle,
+				"LaunchAt":  *instance.launchTime,
+				"Tags":      viewer.Map(instance.tags),
+			}
+			if data.showStatus {
+				for name, value := range instanceStatusFields(instance.status) {
+					fields[name] = value
+				}
+			}
+			tViewer.AddFields(fields)
 		}
 		compoundViewer.AddViewer(tViewer)
 	}
@@ -118,6 +428,7 @@ func instanceInfoViewer(o interface{}) viewer.Viewer {
 
 	cTviewer.AddViewer(renderInstanceSummary(instance.summary))
 	cTviewer.AddViewer(renderInstanceDetails(instance.detail))
+	cTviewer.AddViewer(renderInstanceHealth(instance.health))
 	cTviewer.AddViewers(renderInstanceRulesSummary(instance.ruleSummary))
 	cTviewer.AddViewer(renderInstanceVolumeSummary(instance.volumesSummary))
 	cTviewer.AddViewer(renderInstanceNetworkSummary(instance.networkInterfaces))
@@ -130,18 +441,19 @@ func renderInstanceSummary(o *instanceSummary) *viewer.TableViewer {
 	tViewer := viewer.NewTableViewer()
 	tViewer.SetTitle("Summary")
 	tViewer.AddHeader(instanceSummaryTableHeader)
+	tViewer.SetWideColumns(instanceSummaryWideColumns...)
 
-	tViewer.AddRow(viewer.Row{
-		*o.id,
-		*o.typee,
-		*o.state,
-		*o.publicIp,
-		*o.publicIpDNS,
-		*o.privateIp,
-		*o.privateIpDNS,
-		*o.vpcId,
-		*o.subnetId,
-		*o.iamroleArn,
+	tViewer.AddFields(viewer.Fields{
+		"Id":           *o.id,
+		"Type":         *o.typee,
+		"State":        *o.state,
+		"PublicIp":     *o.publicIp,
+		"PublicIpDNS":  *o.publicIpDNS,
+		"PrivateIp":    *o.privateIp,
+		"PrivateIpDNS": *o.privateIpDNS,
+		"Vpc":          *o.vpcId,
+		"Subnet":       *o.subnetId,
+		"IAMRoleARN":   *o.iamroleArn,
 	})
 	return tViewer
 }
@@ -152,16 +464,41 @@ func renderInstanceDetails(o *instanceDetail) *viewer.TableViewer {
 	tViewer.SetTitle("Details")
 	tViewer.AddHeader(instanceDetailsTableHeader)
 
-	tViewer.AddRow(viewer.Row{
-		*o.platform,
-		*o.amiId,
-		*o.monitor,
-		*o.osdetails,
-		*o.launchTime,
+	tViewer.AddFields(viewer.Fields{
+		"Platform":    *o.platform,
+		"AmiId":       *o.amiId,
+		"Monitoring":  *o.monitor,
+		"OSType":      *o.osdetails,
+		"LaunchTime":  *o.launchTime,
+		"vCPU":        o.vcpus,
+		"Memory(GiB)": o.memory,
 	})
 	return tViewer
 }
 
+func renderInstanceHealth(health *instanceHealth) viewer.Viewer {
+	if health.apiError != nil {
+		errorViewer := viewer.NewErrorViewer()
+		errorViewer.SetErrorMessage(health.apiError.Err.Error())
+		errorViewer.SetErrorType(health.apiError.ErrorType)
+		return errorViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("Health")
+	tViewer.AddHeader(instanceStatusTableHeader)
+	tViewer.AddFields(instanceStatusFields(health.status))
+	return tViewer
+}
+
+func instanceStatusFields(status *instanceStatus) viewer.Fields {
+	return viewer.Fields{
+		"SystemCheck":   status.systemStatus,
+		"InstanceCheck": status.instanceStatus,
+		"Uptime":        status.uptime,
+		"Events":        status.formatEvents(),
+	}
+}
+
 func renderInstanceRulesSummary(summary *instanceIngressEgressRuleSummary) []viewer.Viewer {
 	viewers := []viewer.Viewer{}
 	if summary.apiError != nil {
@@ -183,12 +520,12 @@ func renderInstanceIngressRules(rules []*ingressRule) *viewer.TableViewer {
 	tViewer.AddHeader(instanceSecurityGroupInboundSummaryTableHeader)
 
 	for _, rule := range rules {
-		tViewer.AddRow(viewer.Row{
-			*rule.portRange,
-			*rule.protocol,
-			*rule.source,
-			*rule.sgId,
-			*rule.desc,
+		tViewer.AddFields(viewer.Fields{
+			"PortRange":   *rule.portRange,
+			"Protocol":    *rule.protocol,
+			"Source":      *rule.source,
+			"GroupId":     *rule.sgId,
+			"Description": *rule.desc,
 		})
 	}
 	return tViewer
@@ -200,12 +537,12 @@ func renderInstanceEgressRules(rules []*egressRule) *viewer.TableViewer {
 	tViewer.SetTitle("Egress Rules")
 	tViewer.AddHeader(instanceSecurityGroupOutboundSummaryTableHeader)
 	for _, rule := range rules {
-		tViewer.AddRow(viewer.Row{
-			*rule.portRange,
-			*rule.protocol,
-			*rule.source,
-			*rule.sgId,
-			*rule.desc,
+		tViewer.AddFields(viewer.Fields{
+			"PortRange":   *rule.portRange,
+			"Protocol":    *rule.protocol,
+			"Destination": *rule.source,
+			"GroupId":     *rule.sgId,
+			"Description": *rule.desc,
 		})
 	}
 	return tViewer
@@ -219,15 +556,15 @@ func renderInstanceVolumeSummary(volumesSummary *instanceVolumeSummary) *viewer.
 
 	for _, volume := range volumesSummary.volumes {
 		for _, attachment := range volume.attachments {
-			tViewer.AddRow(viewer.Row{
-				*attachment.id,
-				*attachment.device,
-				*volume.size,
-				*attachment.state,
-				*attachment.time,
-				volume.isEncrypt,
-				*volume.kmsKey,
-				*attachment.deleteOnTermination,
+			tViewer.AddFields(viewer.Fields{
+				"Id":                  *attachment.id,
+				"DeviceName":          *attachment.device,
+				"Size":                *volume.size,
+				"Status":              *attachment.state,
+				"Time":                *attachment.time,
+				"Encrypted":           volume.isEncrypt,
+				"KMS":                 *volume.kmsKey,
+				"DeleteOntermination": *attachment.deleteOnTermination,
 			})
 		}
 	}
@@ -240,6 +577,7 @@ func renderInstanceNetworkSummary(instanceNetworkinterfaces []*instanceNetworkin
 	tViewer := viewer.NewTableViewer()
 	tViewer.SetTitle("Networks")
 	tViewer.AddHeader(instanceNetworkSummaryTableHeader)
+	tViewer.SetWideColumns(instanceNetworkSummaryWideColumns...)
 
 	for _, networkinterface := range instanceNetworkinterfaces {
 
@@ -248,21 +586,1031 @@ func renderInstanceNetworkSummary(instanceNetworkinterfaces []*instanceNetworkin
 			securityGroupsArr = append(securityGroupsArr, *sg)
 		}
 
-		tViewer.AddRow(viewer.Row{
-			*networkinterface.id,
-			*networkinterface.description,
-			*networkinterface.privateIpv4Add,
-			*networkinterface.privateIpv4DNS,
-			*networkinterface.publicIpv4Add,
-			*networkinterface.publicIpv4DNS,
-			*networkinterface.attachTime,
-			*networkinterface.attachStatus,
-			*networkinterface.vpcId,
-			*networkinterface.subnetId,
-			*networkinterface.deleteOnTermination,
-			strings.Join(securityGroupsArr, "\n"),
+		tViewer.AddFields(viewer.Fields{
+			"id":                  *networkinterface.id,
+			"description":         *networkinterface.description,
+			"privateIpv4Add":      *networkinterface.privateIpv4Add,
+			"privateIpv4DNS":      *networkinterface.privateIpv4DNS,
+			"publicIpv4Add":       *networkinterface.publicIpv4Add,
+			"publicIpv4DNS":       *networkinterface.publicIpv4DNS,
+			"attachTime":          *networkinterface.attachTime,
+			"attachStatus":        *networkinterface.attachStatus,
+			"vpcId":               *networkinterface.vpcId,
+			"subnetId":            *networkinterface.subnetId,
+			"deleteOnTermination": *networkinterface.deleteOnTermination,
+			"securityGroups":      strings.Join(securityGroupsArr, "\n"),
+		})
+	}
+
+	return tViewer
+}
+
+func securityGroupListViewer(o interface{}) viewer.Viewer {
+	data := o.(*securityGroupListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("Security Groups")
+	tViewer.AddHeader(securityGroupListTableHeader)
+	tViewer.SetWideColumns(securityGroupListWideColumns...)
+	for _, sg := range data.securityGroups {
+		tViewer.AddFields(viewer.Fields{
+			"Id":           *sg.id,
+			"Name":         *sg.name,
+			"Vpc":          *sg.vpcId,
+			"Description":  *sg.description,
+			"IngressRules": sg.ingressRule,
+			"EgressRules":  sg.egressRule,
+			"Tags":         viewer.Map(sg.tags),
+		})
+	}
+	return tViewer
+}
+
+func securityGroupInfoViewer(o interface{}) viewer.Viewer {
+	data := o.(*securityGroupDefinition)
+	if data.summary == nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	cTviewer := viewer.NewCompoundViewer()
+	summaryViewer := viewer.NewTableViewer()
+	summaryViewer.SetTitle("Summary")
+	summaryViewer.AddHeader(securityGroupListTableHeader)
+	summaryViewer.AddFields(viewer.Fields{
+		"Id":           *data.summary.id,
+		"Name":         *data.summary.name,
+		"Vpc":          *data.summary.vpcId,
+		"Description":  *data.summary.description,
+		"IngressRules": data.summary.ingressRule,
+		"EgressRules":  data.summary.egressRule,
+		"Tags":         viewer.Map(data.summary.tags),
+	})
+	cTviewer.AddViewer(summaryViewer)
+	cTviewer.AddViewers(renderInstanceRulesSummary(data.ruleSummary))
+
+	eniViewer := viewer.NewTableViewer()
+	eniViewer.SetTitle("Network Interfaces")
+	eniViewer.AddHeader(securityGroupNetworkInterfaceTableHeader)
+	for _, eni := range data.networkInterfaces {
+		eniViewer.AddFields(viewer.Fields{
+			"Id":          *eni.id,
+			"Type":        *eni.interfaceType,
+			"Status":      *eni.status,
+			"PrivateIp":   *eni.privateIp,
+			"InstanceId":  *eni.instanceId,
+			"Description": *eni.description,
+		})
+	}
+	cTviewer.AddViewer(eniViewer)
+
+	instanceViewer := viewer.NewTableViewer()
+	instanceViewer.SetTitle("Instances")
+	instanceViewer.AddHeader(securityGroupInstanceTableHeader)
+	for _, instance := range data.instances {
+		instanceViewer.AddFields(viewer.Fields{
+			"Id":    *instance.id,
+			"Name":  *instance.name,
+			"State": *instance.state,
+			"Type":  *instance.typee,
+		})
+	}
+	cTviewer.AddViewer(instanceViewer)
+
+	referenceViewer := viewer.NewTableViewer()
+	referenceViewer.SetTitle("Referenced By")
+	referenceViewer.AddHeader(securityGroupReferenceTableHeader)
+	for _, reference := range data.referencedBy {
+		referenceViewer.AddFields(viewer.Fields{
+			"GroupId":   *reference.sgId,
+			"Direction": reference.direction,
+			"PortRange": *reference.portRange,
+			"Protocol":  *reference.protocol,
+		})
+	}
+	cTviewer.AddViewer(referenceViewer)
+
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		cTviewer.AddViewer(erroViewer)
+	}
+	return cTviewer
+}
+
+func securityGroupAuditViewer(o interface{}) viewer.Viewer {
+	data := o.(*securityGroupAuditOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	cViewer := viewer.NewCompoundViewer()
+	if len(data.findings) != 0 {
+		cViewer.AddViewer(securityGroupFindingViewer(data))
+	}
+	for _, regionErr := range data.regionErrs {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(regionErr.ErrorType)
+		erroViewer.SetErrorMessage(regionErr.Err.Error())
+		cViewer.AddViewer(erroViewer)
+	}
+	return cViewer
+}
+
+func securityGroupFindingViewer(data *securityGroupAuditOutput) viewer.Viewer {
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Security Group Audit (%d groups in %s)", data.scannedGroups, strings.Join(data.regions, ",")))
+	tViewer.AddHeader(securityGroupFindingTableHeader)
+	for _, finding := range data.findings {
+		exposed := NO_VALUE
+		if len(finding.exposed) != 0 {
+			exposed = strings.Join(finding.exposed, "\n")
+		}
+		tViewer.AddFields(viewer.Fields{
+			"Severity":  finding.severity,
+			"Region":    finding.region,
+			"GroupId":   finding.sgId,
+			"Direction": finding.direction,
+			"PortRange": finding.portRange,
+			"Protocol":  finding.protocol,
+			"Source":    finding.source,
+			"Finding":   finding.finding,
+			"Exposed":   exposed,
+		})
+	}
+	return tViewer
+}
+
+func reachabilityViewer(o interface{}) viewer.Viewer {
+	data := o.(*reachabilityOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	verdict := "REACHABLE"
+	if !data.reachable {
+		verdict = "NOT REACHABLE"
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("%s -> %s %s/%d: %s", data.from, data.to, strings.ToUpper(data.protocol), data.port, verdict))
+	tViewer.AddHeader(reachabilityCheckTableHeader)
+	for _, check := range data.checks {
+		tViewer.AddFields(viewer.Fields{
+			"Step":    check.step,
+			"Verdict": check.verdict,
+			"Rule":    check.rule,
+			"Reason":  check.reason,
+		})
+	}
+	return tViewer
+}
+
+func vpcListViewer(o interface{}) viewer.Viewer {
+	data := o.(*vpcListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("VPCs")
+	tViewer.AddHeader(vpcListTableHeader)
+	for _, vpc := range data.vpcs {
+		tViewer.AddFields(vpcSummaryFields(vpc))
+	}
+	return tViewer
+}
+
+func vpcInfoViewer(o interface{}) viewer.Viewer {
+	data := o.(*vpcDefinition)
+	if data.summary == nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	cTviewer := viewer.NewCompoundViewer()
+	summaryViewer := viewer.NewTableViewer()
+	summaryViewer.SetTitle("Summary")
+	summaryViewer.AddHeader(vpcListTableHeader)
+	summaryViewer.AddFields(vpcSummaryFields(data.summary))
+	cTviewer.AddViewer(summaryViewer)
+	cTviewer.AddViewer(renderSubnets(data.subnets))
+
+	routeTableViewer := viewer.NewTableViewer()
+	routeTableViewer.SetTitle("Route Tables")
+	routeTableViewer.AddHeader(routeTableTableHeader)
+	for _, routeTable := range data.routeTables {
+		associations := NO_VALUE
+		if len(routeTable.associations) != 0 {
+			associations = strings.Join(routeTable.associations, "\n")
+		}
+		destinations, targets, states := []string{}, []string{}, []string{}
+		for _, route := range routeTable.routes {
+			destinations = append(destinations, route.destination)
+			targets = append(targets, route.target)
+			states = append(states, route.state)
+		}
+		routeTableViewer.AddFields(viewer.Fields{
+			"Id":           *routeTable.id,
+			"Name":         routeTable.name,
+			"Main":         routeTable.main,
+			"Associations": associations,
+			"Destination":  strings.Join(destinations, "\n"),
+			"Target":       strings.Join(targets, "\n"),
+			"State":        strings.Join(states, "\n"),
+		})
+	}
+	cTviewer.AddViewer(routeTableViewer)
+
+	gatewayViewer := viewer.NewTableViewer()
+	gatewayViewer.SetTitle("Gateways")
+	gatewayViewer.AddHeader(vpcGatewayTableHeader)
+	for _, gateway := range data.gateways {
+		gatewayViewer.AddFields(viewer.Fields{
+			"Id":        *gateway.id,
+			"Name":      gateway.name,
+			"Type":      gateway.gwType,
+			"State":     gateway.state,
+			"Subnet":    gateway.subnetId,
+			"PublicIp":  gateway.publicIp,
+			"PrivateIp": gateway.privateIp,
+		})
+	}
+	cTviewer.AddViewer(gatewayViewer)
+
+	endpointViewer := viewer.NewTableViewer()
+	endpointViewer.SetTitle("Endpoints")
+	endpointViewer.AddHeader(vpcEndpointTableHeader)
+	for _, endpoint := range data.endpoints {
+		endpointViewer.AddFields(viewer.Fields{
+			"Id":          *endpoint.id,
+			"ServiceName": *endpoint.serviceName,
+			"Type":        *endpoint.typee,
+			"State":       *endpoint.state,
 		})
 	}
+	cTviewer.AddViewer(endpointViewer)
 
+	peeringViewer := viewer.NewTableViewer()
+	peeringViewer.SetTitle("Peering Connections")
+	peeringViewer.AddHeader(vpcPeeringConnectionTableHeader)
+	for _, peering := range data.peeringConnections {
+		peeringViewer.AddFields(viewer.Fields{
+			"Id":        *peering.id,
+			"Requester": peering.requester,
+			"Accepter":  peering.accepter,
+			"Status":    peering.status,
+		})
+	}
+	cTviewer.AddViewer(peeringViewer)
+
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		cTviewer.AddViewer(erroViewer)
+	}
+	return cTviewer
+}
+
+func subnetListViewer(o interface{}) viewer.Viewer {
+	data := o.(*subnetListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	return renderSubnets(data.subnets)
+}
+
+func vpcSummaryFields(vpc *vpcSummary) viewer.Fields {
+	return viewer.Fields{
+		"Id":        *vpc.id,
+		"Name":      vpc.name,
+		"Cidr":      strings.Join(vpc.cidrs, "\n"),
+		"State":     *vpc.state,
+		"Default":   vpc.isDefault,
+		"Subnets":   vpc.subnets,
+		"Instances": vpc.instances,
+	}
+}
+
+func renderSubnets(subnets []*subnetSummary) *viewer.TableViewer {
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("Subnets")
+	tViewer.AddHeader(subnetListTableHeader)
+	tViewer.SetWideColumns(subnetListWideColumns...)
+	for _, subnet := range subnets {
+		tViewer.AddFields(viewer.Fields{
+			"Id":               *subnet.id,
+			"Name":             subnet.name,
+			"Vpc":              *subnet.vpcId,
+			"Az":               *subnet.az,
+			"Cidr":             *subnet.cidr,
+			"AvailableIps":     *subnet.availableIps,
+			"PublicIpOnLaunch": subnet.mapPublicIpOnLaunch,
+			"RouteTable":       subnet.routeTableId,
+			"Instances":        subnet.instances,
+		})
+	}
+	return tViewer
+}
+
+func volumeListViewer(o interface{}) viewer.Viewer {
+	data := o.(*volumeListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	return renderVolumes(fmt.Sprintf("Volumes (%d, %d GiB)", len(data.volumes), data.totalSize), data.volumes)
+}
+
+func snapshotListViewer(o interface{}) viewer.Viewer {
+	data := o.(*snapshotListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	return renderSnapshots(fmt.Sprintf("Snapshots (%d, %d GiB)", len(data.snapshots), data.totalSize), data.snapshots)
+}
+
+func snapshotCreateViewer(o interface{}) viewer.Viewer {
+	data := o.(*snapshotCreateOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	return renderSnapshots(fmt.Sprintf("[%s]: Snapshots Created", data.instanceId), data.snapshots)
+}
+
+func volumeCleanupViewer(o interface{}) viewer.Viewer {
+	data := o.(*volumeCleanupOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	if data.deletions != nil {
+		return renderResourceDeletions("Cleanup Summary", data.deletions)
+	}
+
+	suffix := ""
+	if len(data.olderThan) != 0 {
+		suffix = fmt.Sprintf(" older than %s", data.olderThan)
+	}
+	cTviewer := viewer.NewCompoundViewer()
+	cTviewer.AddViewer(renderVolumes("Unattached Volumes"+suffix, data.volumes))
+	cTviewer.AddViewer(renderSnapshots("Snapshots Of Deleted Volumes"+suffix, data.snapshots))
+	totalViewer := viewer.NewTableViewer()
+	totalViewer.SetTitle("Total")
+	totalViewer.AddHeader(cleanupTotalTableHeader)
+	totalViewer.AddFields(viewer.Fields{"Resource": "volume", "Count": len(data.volumes), "Size(GiB)": data.volumeSize})
+	totalViewer.AddFields(viewer.Fields{"Resource": "snapshot", "Count": len(data.snapshots), "Size(GiB)": data.snapshotSize})
+	totalViewer.AddFields(viewer.Fields{"Resource": "total", "Count": len(data.volumes) + len(data.snapshots), "Size(GiB)": data.volumeSize + data.snapshotSize})
+	cTviewer.AddViewer(totalViewer)
+	return cTviewer
+}
+
+func renderVolumes(title string, volumes []*volumeSummary) *viewer.TableViewer {
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(title)
+	tViewer.AddHeader(volumeListTableHeader)
+	tViewer.SetWideColumns(volumeListWideColumns...)
+	for _, volume := range volumes {
+		tViewer.AddFields(viewer.Fields{
+			"Id":         *volume.id,
+			"Name":       volume.name,
+			"Type":       *volume.typee,
+			"Size(GiB)":  volume.size,
+			"Iops":       volume.iops,
+			"State":      *volume.state,
+			"Az":         *volume.az,
+			"Encrypted":  volume.encrypted,
+			"AttachedTo": volume.attachedTo,
+			"CreateTime": *volume.createTime,
+		})
+	}
+	return tViewer
+}
+
+func renderSnapshots(title string, snapshots []*snapshotSummary) *viewer.TableViewer {
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(title)
+	tViewer.AddHeader(snapshotListTableHeader)
+	tViewer.SetWideColumns(snapshotListWideColumns...)
+	for _, snapshot := range snapshots {
+		tViewer.AddFields(viewer.Fields{
+			"Id":          *snapshot.id,
+			"Name":        snapshot.name,
+			"VolumeId":    *snapshot.volumeId,
+			"Size(GiB)":   snapshot.size,
+			"State":       *snapshot.state,
+			"Progress":    snapshot.progress,
+			"Encrypted":   snapshot.encrypted,
+			"StartTime":   *snapshot.startTime,
+			"Age":         snapshot.age,
+			"Description": snapshot.description,
+		})
+	}
+	return tViewer
+}
+
+func imageListViewer(o interface{}) viewer.Viewer {
+	data := o.(*imageListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	return renderImages("Images", data.images)
+}
+
+func imageInfoViewer(o interface{}) viewer.Viewer {
+	data := o.(*imageDefinition)
+	if data.summary == nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	cTviewer := viewer.NewCompoundViewer()
+	summaryViewer := renderImages("Summary", []*imageSummary{data.summary})
+	cTviewer.AddViewer(summaryViewer)
+
+	detailViewer := viewer.NewTableViewer()
+	detailViewer.SetTitle("Details")
+	detailViewer.AddHeader(viewer.Row{"Description", "DeprecationTime", "Tags"})
+	detailViewer.AddFields(viewer.Fields{"Description": data.description, "DeprecationTime": data.deprecationTime, "Tags": viewer.Map(data.tags)})
+	cTviewer.AddViewer(detailViewer)
+
+	deviceViewer := viewer.NewTableViewer()
+	deviceViewer.SetTitle("Block Devices")
+	deviceViewer.AddHeader(imageBlockDeviceTableHeader)
+	for _, device := range data.blockDevices {
+		deviceViewer.AddFields(viewer.Fields{
+			"Device":              device.device,
+			"SnapshotId":          device.snapshotId,
+			"SourceVolume":        device.sourceVolumeId,
+			"Size(GiB)":           device.size,
+			"VolumeType":          device.volumeType,
+			"Encrypted":           device.encrypted,
+			"DeleteOnTermination": device.deleteOnTermination,
+		})
+	}
+	cTviewer.AddViewer(deviceViewer)
+
+	instanceViewer := viewer.NewTableViewer()
+	instanceViewer.SetTitle("Used By Instances")
+	instanceViewer.AddHeader(securityGroupInstanceTableHeader)
+	for _, instance := range data.instances {
+		instanceViewer.AddFields(viewer.Fields{
+			"Id":    *instance.id,
+			"Name":  *instance.name,
+			"State": *instance.state,
+			"Type":  *instance.typee,
+		})
+	}
+	cTviewer.AddViewer(instanceViewer)
+
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		cTviewer.AddViewer(erroViewer)
+	}
+	return cTviewer
+}
+
+func imageUnusedViewer(o interface{}) viewer.Viewer {
+	data := o.(*imageUnusedOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	if data.deletions != nil {
+		return renderResourceDeletions("Deregister Summary", data.deletions)
+	}
+	title := fmt.Sprintf("Unused Images (%d, %d GiB of snapshots)", len(data.images), data.totalSize)
+	if len(data.olderThan) != 0 {
+		title = fmt.Sprintf("Unused Images older than %s (%d, %d GiB of snapshots)", data.olderThan, len(data.images), data.totalSize)
+	}
+	return renderImages(title, data.images)
+}
+
+func renderImages(title string, images []*imageSummary) *viewer.TableViewer {
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(title)
+	tViewer.AddHeader(imageListTableHeader)
+	tViewer.SetWideColumns(imageListWideColumns...)
+	for _, image := range images {
+		snapshots := NO_VALUE
+		if len(image.snapshots) != 0 {
+			snapshots = strings.Join(image.snapshots, "\n")
+		}
+		tViewer.AddFields(viewer.Fields{
+			"Id":           *image.id,
+			"Name":         image.name,
+			"CreationDate": image.creationDate,
+			"Age":          image.age,
+			"Architecture": image.architecture,
+			"RootDevice":   image.rootDevice,
+			"State":        *image.state,
+			"Public":       image.public,
+			"Snapshots":    snapshots,
+			"Size(GiB)":    image.size,
+		})
+	}
+	return tViewer
+}
+
+func renderResourceDeletions(title string, deletions []*resourceDeletion) *viewer.TableViewer {
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(title)
+	tViewer.AddHeader(resourceDeletionTableHeader)
+	for _, deletion := range deletions {
+		if deletion.err != nil {
+			tViewer.AddFields(viewer.Fields{"Id": deletion.id, "Status": "failed", "Error": deletion.err.Err.Error()})
+		} else {
+			tViewer.AddFields(viewer.Fields{"Id": deletion.id, "Status": "deleted", "Error": NO_VALUE})
+		}
+	}
+	return tViewer
+}
+
+func elasticIpListViewer(o interface{}) viewer.Viewer {
+	data := o.(*elasticIpListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Elastic IPs (%d, %d unassociated)", len(data.addresses), data.unassociated))
+	tViewer.AddHeader(elasticIpListTableHeader)
+	tViewer.SetWideColumns(elasticIpListWideColumns...)
+	for _, address := range data.addresses {
+		tViewer.AddFields(viewer.Fields{
+			"PublicIp":         *address.publicIp,
+			"AllocationId":     address.allocationId,
+			"Name":             address.name,
+			"AssociationId":    address.associationId,
+			"InstanceId":       address.instanceId,
+			"NetworkInterface": address.networkInterfaceId,
+			"PrivateIp":        address.privateIp,
+			"Domain":           address.domain,
+		})
+	}
+	return tViewer
+}
+
+func networkInterfaceListViewer(o interface{}) viewer.Viewer {
+	data := o.(*networkInterfaceListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Network Interfaces (%d, %d available)", len(data.networkInterfaces), data.available))
+	tViewer.AddHeader(networkInterfaceListTableHeader)
+	tViewer.SetWideColumns(networkInterfaceListWideColumns...)
+	for _, eni := range data.networkInterfaces {
+		securityGroups := NO_VALUE
+		if len(eni.securityGroups) != 0 {
+			securityGroups = strings.Join(eni.securityGroups, "\n")
+		}
+		requesterManaged := fmt.Sprintf("%t", eni.requesterManaged)
+		if eni.requesterManaged {
+			requesterManaged = fmt.Sprintf("true(%s)", eni.requesterId)
+		}
+		tViewer.AddFields(viewer.Fields{
+			"Id":               *eni.id,
+			"Name":             eni.name,
+			"Type":             eni.interfaceType,
+			"Status":           *eni.status,
+			"InstanceId":       eni.instanceId,
+			"InstanceOwner":    eni.instanceOwnerId,
+			"PrivateIp":        eni.privateIp,
+			"PublicIp":         eni.publicIp,
+			"Vpc":              eni.vpcId,
+			"Subnet":           eni.subnetId,
+			"SecurityGroups":   securityGroups,
+			"RequesterManaged": requesterManaged,
+			"Description":      eni.description,
+		})
+	}
+	return tViewer
+}
+
+func connectViewer(o interface{}) viewer.Viewer {
+	data := o.(*connectOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("Session")
+	tViewer.AddHeader(connectTableHeader)
+	tViewer.AddFields(viewer.Fields{
+		"InstanceId": data.instanceId,
+		"Name":       data.name,
+		"Method":     data.method,
+		"Target":     data.target,
+		"Forward":    data.forward,
+		"Status":     "closed",
+	})
+	return tViewer
+}
+
+func consoleOutputViewer(o interface{}) viewer.Viewer {
+	data := o.(*consoleOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	cTviewer := viewer.NewCompoundViewer()
+	if len(strings.TrimSpace(data.content)) != 0 {
+		textViewer := viewer.NewTextViewer()
+		timestamp := NO_VALUE
+		if data.timestamp != nil {
+			timestamp = ctltime.Format(*data.timestamp)
+		}
+		textViewer.SetTitle(fmt.Sprintf("[%s]: Console Output (%s)", data.instanceId, timestamp))
+		textViewer.SetContent(data.content)
+		cTviewer.AddViewer(textViewer)
+	}
+	if len(data.screenshotFile) != 0 {
+		textViewer := viewer.NewTextViewer()
+		textViewer.SetContent(fmt.Sprintf("Screenshot saved to %s", data.screenshotFile))
+		cTviewer.AddViewer(textViewer)
+	}
+	return cTviewer
+}
+
+func userDataViewer(o interface{}) viewer.Viewer {
+	data := o.(*userDataOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	cTviewer := viewer.NewCompoundViewer()
+	for i, part := range data.parts {
+		title := fmt.Sprintf("[%s]: User Data part %d/%d, type: %s, file: %s", data.instanceId, i+1, len(data.parts), part.contentType, part.filename)
+		if part.compressed {
+			title += " (gzip)"
+		}
+		textViewer := viewer.NewTextViewer()
+		textViewer.SetTitle(title)
+		textViewer.SetContent(part.content)
+		cTviewer.AddViewer(textViewer)
+	}
+	return cTviewer
+}
+
+func instanceTypeListViewer(o interface{}) viewer.Viewer {
+	data := o.(*instanceTypeListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Instance Types (%d)", len(data.instanceTypes)))
+	tViewer.AddHeader(instanceTypeListTableHeader)
+	tViewer.SetWideColumns(instanceTypeListWideColumns...)
+	for _, instanceType := range data.instanceTypes {
+		tViewer.AddFields(viewer.Fields{
+			"Type":              instanceType.typee,
+			"vCPU":              instanceType.vcpus,
+			"Memory(GiB)":       formatMemory(instanceType.memory),
+			"Architecture":      instanceType.architectures,
+			"GPU":               instanceType.gpus,
+			"Network":           instanceType.network,
+			"EbsOptimized":      instanceType.ebsOptimized,
+			"InstanceStorage":   instanceType.instanceStorage,
+			"CurrentGeneration": instanceType.currentGeneration,
+		})
+	}
+	return tViewer
+}
+
+// instanceTypeCompareViewer render specs as rows & instance types as columns
+func instanceTypeCompareViewer(o interface{}) viewer.Viewer {
+	data := o.(*instanceTypeCompareOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	specs := []struct {
+		name  string
+		value func(*instanceTypeSummary) interface{}
+	}{
+		{"vCPU", func(t *instanceTypeSummary) interface{} { return t.vcpus }},
+		{"Cores", func(t *instanceTypeSummary) interface{} { return t.cores }},
+		{"ThreadsPerCore", func(t *instanceTypeSummary) interface{} { return t.threadsPerCore }},
+		{"Memory(GiB)", func(t *instanceTypeSummary) interface{} { return formatMemory(t.memory) }},
+		{"Architecture", func(t *instanceTypeSummary) interface{} { return t.architectures }},
+		{"ClockSpeed", func(t *instanceTypeSummary) interface{} { return t.clockSpeed }},
+		{"GPU", func(t *instanceTypeSummary) interface{} { return t.gpuDetail }},
+		{"Network", func(t *instanceTypeSummary) interface{} { return t.network }},
+		{"MaxNetworkInterfaces", func(t *instanceTypeSummary) interface{} { return t.maxEnis }},
+		{"EbsOptimized", func(t *instanceTypeSummary) interface{} { return t.ebsOptimized }},
+		{"EbsBaselineBandwidth", func(t *instanceTypeSummary) interface{} { return t.ebsBandwidth }},
+		{"InstanceStorage", func(t *instanceTypeSummary) interface{} { return t.instanceStorage }},
+		{"Burstable", func(t *instanceTypeSummary) interface{} { return t.burstable }},
+		{"CurrentGeneration", func(t *instanceTypeSummary) interface{} { return t.currentGeneration }},
+		{"Hypervisor", func(t *instanceTypeSummary) interface{} { return t.hypervisor }},
+	}
+	header := viewer.Row{"Spec"}
+	for _, instanceType := range data.instanceTypes {
+		header = append(header, instanceType.typee)
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle("Instance Type Comparison")
+	tViewer.AddHeader(header)
+	for _, spec := range specs {
+		fields := viewer.Fields{"Spec": spec.name}
+		for _, instanceType := range data.instanceTypes {
+			fields[instanceType.typee] = spec.value(instanceType)
+		}
+		tViewer.AddFields(fields)
+	}
+	return tViewer
+}
+
+func launchTemplateListViewer(o interface{}) viewer.Viewer {
+	data := o.(*launchTemplateListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Launch Templates (%d)", len(data.launchTemplates)))
+	tViewer.AddHeader(launchTemplateListTableHeader)
+	tViewer.SetWideColumns(launchTemplateListWideColumns...)
+	for _, template := range data.launchTemplates {
+		tViewer.AddFields(viewer.Fields{
+			"Id":             *template.id,
+			"Name":           template.name,
+			"DefaultVersion": template.defaultVersion,
+			"LatestVersion":  template.latestVersion,
+			"CreatedBy":      template.createdBy,
+			"CreatedAt":      *template.createdTime,
+		})
+	}
+	return tViewer
+}
+
+func launchTemplateInfoViewer(o interface{}) viewer.Viewer {
+	data := o.(*launchTemplateDefinition)
+	if data.summary == nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+
+	cTviewer := viewer.NewCompoundViewer()
+	summaryViewer := viewer.NewTableViewer()
+	summaryViewer.SetTitle("Summary")
+	summaryViewer.AddHeader(launchTemplateVersionTableHeader)
+	summaryViewer.AddFields(viewer.Fields{
+		"Id":             *data.summary.id,
+		"Name":           data.summary.name,
+		"Version":        data.version,
+		"DefaultVersion": data.summary.defaultVersion,
+		"LatestVersion":  data.summary.latestVersion,
+		"Description":    data.versionDescription,
+		"CreatedBy":      data.versionCreatedBy,
+		"CreatedAt":      *data.versionCreatedTime,
+	})
+	cTviewer.AddViewer(summaryViewer)
+
+	instanceViewer := viewer.NewTableViewer()
+	instanceViewer.SetTitle("Instance")
+	instanceViewer.AddHeader(launchTemplateInstanceTableHeader)
+	instanceViewer.AddFields(viewer.Fields{
+		"AMI":          data.imageId,
+		"Type":         data.instanceType,
+		"KeyPair":      data.keyName,
+		"IamProfile":   data.iamProfile,
+		"EbsOptimized": data.ebsOptimized,
+		"HttpTokens":   data.httpTokens,
+	})
+	cTviewer.AddViewer(instanceViewer)
+
+	sgViewer := viewer.NewTableViewer()
+	sgViewer.SetTitle("Security Groups")
+	sgViewer.AddHeader(viewer.Row{"Id", "Name"})
+	groupIds := make([]string, 0, len(data.securityGroups))
+	for id := range data.securityGroups {
+		groupIds = append(groupIds, id)
+	}
+	sort.Strings(groupIds)
+	for _, id := range groupIds {
+		sgViewer.AddFields(viewer.Fields{"Id": id, "Name": data.securityGroups[id]})
+	}
+	cTviewer.AddViewer(sgViewer)
+
+	deviceViewer := viewer.NewTableViewer()
+	deviceViewer.SetTitle("Block Devices")
+	deviceViewer.AddHeader(launchTemplateBlockDeviceTableHeader)
+	for _, device := range data.blockDevices {
+		deviceViewer.AddFields(viewer.Fields{
+			"Device":              device.device,
+			"SnapshotId":          device.snapshotId,
+			"Size(GiB)":           device.size,
+			"VolumeType":          device.volumeType,
+			"Iops":                device.iops,
+			"Throughput":          device.throughput,
+			"Encrypted":           device.encrypted,
+			"DeleteOnTermination": device.deleteOnTermination,
+		})
+	}
+	cTviewer.AddViewer(deviceViewer)
+
+	if len(data.networkInterfaces) != 0 {
+		eniViewer := viewer.NewTableViewer()
+		eniViewer.SetTitle("Network Interfaces")
+		eniViewer.AddHeader(launchTemplateNetworkInterfaceTableHeader)
+		for _, eni := range data.networkInterfaces {
+			eniViewer.AddFields(viewer.Fields{
+				"DeviceIndex":    eni.deviceIndex,
+				"SubnetId":       eni.subnetId,
+				"PublicIp":       eni.publicIp,
+				"SecurityGroups": eni.securityGroups,
+			})
+		}
+		cTviewer.AddViewer(eniViewer)
+	}
+
+	for i, part := range data.userData {
+		title := fmt.Sprintf("User Data part %d/%d, type: %s, file: %s", i+1, len(data.userData), part.contentType, part.filename)
+		if part.compressed {
+			title += " (gzip)"
+		}
+		textViewer := viewer.NewTextViewer()
+		textViewer.SetTitle(title)
+		textViewer.SetContent(part.content)
+		cTviewer.AddViewer(textViewer)
+	}
+
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		cTviewer.AddViewer(erroViewer)
+	}
+	return cTviewer
+}
+
+func launchTemplateDiffViewer(o interface{}) viewer.Viewer {
+	data := o.(*launchTemplateDiffOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	cTviewer := viewer.NewCompoundViewer()
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("[%s]: version %d => %d", data.id, data.from, data.to))
+	tViewer.AddHeader(launchTemplateDiffTableHeader)
+	for _, change := range data.changes {
+		tViewer.AddFields(viewer.Fields{"Field": change.field, "From": change.from, "To": change.to, "Change": change.change})
+	}
+	cTviewer.AddViewer(tViewer)
+	if data.fromUserData != data.toUserData {
+		diffViewer := viewer.NewDiffViewer()
+		diffViewer.SetTitle(fmt.Sprintf("[%s]: user data version %d => %d", data.id, data.from, data.to))
+		diffViewer.SetContent(data.fromUserData, data.toUserData)
+		cTviewer.AddViewer(diffViewer)
+	}
+	return cTviewer
+}
+
+func spotRequestListViewer(o interface{}) viewer.Viewer {
+	data := o.(*spotRequestListOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Spot Instance Requests (%d)", len(data.spotRequests)))
+	tViewer.AddHeader(spotRequestListTableHeader)
+	tViewer.SetWideColumns(spotRequestListWideColumns...)
+	for _, request := range data.spotRequests {
+		tViewer.AddFields(viewer.Fields{
+			"Id":           *request.id,
+			"State":        request.state,
+			"Status":       request.status,
+			"Type":         request.requestType,
+			"InstanceType": request.instanceType,
+			"Az":           request.az,
+			"MaxPrice":     request.maxPrice,
+			"InstanceId":   request.instanceId,
+			"CreatedAt":    *request.createdTime,
+		})
+	}
+	return tViewer
+}
+
+func spotPriceHistoryViewer(o interface{}) viewer.Viewer {
+	data := o.(*spotPriceHistoryOutput)
+	if data.err != nil {
+		erroViewer := viewer.NewErrorViewer()
+		erroViewer.SetErrorType(data.err.ErrorType)
+		erroViewer.SetErrorMessage(data.err.Err.Error())
+		return erroViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Spot Prices (USD/hour) from %s to %s", ctltime.Format(data.start), ctltime.Format(data.end)))
+	tViewer.AddHeader(spotPriceTableHeader)
+	for _, price := range data.prices {
+		tViewer.AddFields(viewer.Fields{
+			"Type":    price.typee,
+			"Az":      price.az,
+			"Product": price.product,
+			"Current": formatPrice(price.current),
+			"Min":     formatPrice(price.min),
+			"Max":     formatPrice(price.max),
+			"Avg":     formatPrice(price.average),
+			"Changes": price.changes,
+			"Trend":   price.sparkline,
+		})
+	}
+	return tViewer
+}
+
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', 4, 64)
+}
+
+// instanceGroupSummaryViewer render instance count, vCPU & memory per group with total
+func instanceGroupSummaryViewer(data *instanceListOutput) viewer.Viewer {
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("Instances Summary by %s", data.groupBy))
+	tViewer.AddHeader(viewer.Row{"Group", "Instances", "vCPU", "Memory(GiB)"})
+	var count int
+	var vcpus int64
+	var memory float64
+	for _, group := range data.groups {
+		name := group.name
+		if len(name) == 0 {
+			name = "all"
+		}
+		tViewer.AddFields(viewer.Fields{"Group": name, "Instances": len(group.instances), "vCPU": group.vcpus, "Memory(GiB)": formatMemory(group.memory)})
+		count += len(group.instances)
+		vcpus += group.vcpus
+		memory += group.memory
+	}
+	if len(data.groups) > 1 {
+		tViewer.AddFields(viewer.Fields{"Group": "Total", "Instances": count, "vCPU": vcpus, "Memory(GiB)": formatMemory(memory)})
+	}
 	return tViewer
 }
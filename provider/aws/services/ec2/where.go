@@ -0,0 +1,216 @@
+package ec2
+
+import (
+	"cloudctl/expr"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// fields of --where expression per resource
+var (
+	InstanceSchema = expr.Schema{
+		"id":           expr.STRING,
+		"name":         expr.STRING,
+		"state":        expr.STRING,
+		"type":         expr.STRING,
+		"az":           expr.STRING,
+		"vpc":          expr.STRING,
+		"subnet":       expr.STRING,
+		"publicIp":     expr.STRING,
+		"privateIp":    expr.STRING,
+		"imageId":      expr.STRING,
+		"keyName":      expr.STRING,
+		"architecture": expr.STRING,
+		"platform":     expr.STRING,
+		"lifecycle":    expr.STRING,
+		"asg":          expr.STRING,
+		"vcpus":        expr.NUMBER,
+		"launchTime":   expr.TIME,
+		"tags":         expr.MAP,
+	}
+	VolumeSchema = expr.Schema{
+		"id":         expr.STRING,
+		"name":       expr.STRING,
+		"type":       expr.STRING,
+		"state":      expr.STRING,
+		"az":         expr.STRING,
+		"size":       expr.NUMBER,
+		"iops":       expr.NUMBER,
+		"encrypted":  expr.BOOL,
+		"createTime": expr.TIME,
+		"tags":       expr.MAP,
+	}
+	SnapshotSchema = expr.Schema{
+		"id":        expr.STRING,
+		"name":      expr.STRING,
+		"volumeId":  expr.STRING,
+		"state":     expr.STRING,
+		"owner":     expr.STRING,
+		"size":      expr.NUMBER,
+		"encrypted": expr.BOOL,
+		"startTime": expr.TIME,
+		"tags":      expr.MAP,
+	}
+	ImageSchema = expr.Schema{
+		"id":             expr.STRING,
+		"name":           expr.STRING,
+		"state":          expr.STRING,
+		"architecture":   expr.STRING,
+		"rootDeviceType": expr.STRING,
+		"public":         expr.BOOL,
+		"creationDate":   expr.TIME,
+		"tags":           expr.MAP,
+	}
+)
+
+// api filter names of fields which can be pushed down, tags are pushed down as tag:<key>
+var (
+	instanceFilterNames = map[string]string{
+		"id":           "instance-id",
+		"name":         tag_key_prefix + "Name",
+		"state":        instance_state_name_key,
+		"type":         instance_type_key,
+		"az":           az_key,
+		"vpc":          vpc_id_key,
+		"subnet":       subnet_id_key,
+		"publicIp":     "ip-address",
+		"privateIp":    "private-ip-address",
+		"imageId":      image_id_key,
+		"keyName":      "key-name",
+		"architecture": architecture_key,
+		"asg":          tag_key_prefix + asg_name_tag_key,
+	}
+	volumeFilterNames = map[string]string{
+		"id":        volume_id_key,
+		"name":      tag_key_prefix + "Name",
+		"type":      volume_type_key,
+		"state":     status_key,
+		"az":        az_key,
+		"size":      "size",
+		"encrypted": encrypted_key,
+	}
+	snapshotFilterNames = map[string]string{
+		"id":        "snapshot-id",
+		"name":      tag_key_prefix + "Name",
+		"volumeId":  volume_id_key,
+		"state":     status_key,
+		"owner":     "owner-id",
+		"encrypted": encrypted_key,
+	}
+	imageFilterNames = map[string]string{
+		"id":             image_id_key,
+		"name":           name_key,
+		"state":          state_key,
+		"architecture":   architecture_key,
+		"rootDeviceType": "root-device-type",
+		"public":         "is-public",
+	}
+)
+
+// pushdownFilters convert equalities of where into api filters, values with wildcard are skipped as
+// api filters would treat them as patterns while expression compare them literally
+func pushdownFilters(where *expr.Expression, filterNames map[string]string) []*ec2.Filter {
+	filters := []*ec2.Filter{}
+	if where == nil {
+		return filters
+	}
+	for _, equality := range where.Equalities() {
+		name, ok := filterNames[equality.Field]
+		if len(equality.Key) != 0 {
+			name, ok = tag_key_prefix+equality.Key, equality.Field == "tags"
+		}
+		if !ok || strings.ContainsAny(strings.Join(equality.Values, ""), `*?\`) {
+			continue
+		}
+		filters = append(filters, newFilter(name, equality.Values...))
+	}
+	return filters
+}
+
+func instanceRecord(instance *ec2.Instance) expr.Record {
+	record := expr.Record{
+		"id":           instance.InstanceId,
+		"name":         tagValue(instance.Tags, "Name"),
+		"type":         instance.InstanceType,
+		"vpc":          instance.VpcId,
+		"subnet":       instance.SubnetId,
+		"publicIp":     instance.PublicIpAddress,
+		"privateIp":    instance.PrivateIpAddress,
+		"imageId":      instance.ImageId,
+		"keyName":      instance.KeyName,
+		"architecture": instance.Architecture,
+		"platform":     instance.Platform,
+		"lifecycle":    instanceLifecycle(instance),
+		"asg":          tagValue(instance.Tags, asg_name_tag_key),
+		"launchTime":   instance.LaunchTime,
+		"tags":         newTags(instance.Tags),
+	}
+	if instance.State != nil {
+		record["state"] = instance.State.Name
+	}
+	if instance.Placement != nil {
+		record["az"] = instance.Placement.AvailabilityZone
+	}
+	if instance.CpuOptions != nil {
+		record["vcpus"] = aws.Int64Value(instance.CpuOptions.CoreCount) * aws.Int64Value(instance.CpuOptions.ThreadsPerCore)
+	}
+	return record
+}
+
+func volumeRecord(volume *ec2.Volume) expr.Record {
+	return expr.Record{
+		"id":         volume.VolumeId,
+		"name":       tagValue(volume.Tags, "Name"),
+		"type":       volume.VolumeType,
+		"state":      volume.State,
+		"az":         volume.AvailabilityZone,
+		"size":       volume.Size,
+		"iops":       volume.Iops,
+		"encrypted":  volume.Encrypted,
+		"createTime": volume.CreateTime,
+		"tags":       newTags(volume.Tags),
+	}
+}
+
+func snapshotRecord(snapshot *ec2.Snapshot) expr.Record {
+	return expr.Record{
+		"id":        snapshot.SnapshotId,
+		"name":      tagValue(snapshot.Tags, "Name"),
+		"volumeId":  snapshot.VolumeId,
+		"state":     snapshot.State,
+		"owner":     snapshot.OwnerId,
+		"size":      snapshot.VolumeSize,
+		"encrypted": snapshot.Encrypted,
+		"startTime": snapshot.StartTime,
+		"tags":      newTags(snapshot.Tags),
+	}
+}
+
+func imageRecord(image *ec2.Image) expr.Record {
+	record := expr.Record{
+		"id":             image.ImageId,
+		"name":           image.Name,
+		"state":          image.State,
+		"architecture":   image.Architecture,
+		"rootDeviceType": image.RootDeviceType,
+		"public":         image.Public,
+		"tags":           newTags(image.Tags),
+	}
+	if creationDate, err := time.Parse(time.RFC3339, aws.StringValue(image.CreationDate)); err == nil {
+		record["creationDate"] = creationDate
+	}
+	return record
+}
+
+// tagValue return nil if not tagged, so untagged resources never match name == ...
+func tagValue(tags []*ec2.Tag, key string) *string {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return tag.Value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package ec2
+
+import (
+	"cloudctl/expr"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestPushdownFilters(t *testing.T) {
+	tests := []struct {
+		where string
+		// api filter name to values
+		want map[string][]string
+	}{
+		{`state == "running"`, map[string][]string{instance_state_name_key: {"running"}}},
+		{
+			`(type == "t3.micro" || type == "t3.small") && vpc == "vpc-1"`,
+			map[string][]string{instance_type_key: {"t3.micro", "t3.small"}, vpc_id_key: {"vpc-1"}},
+		},
+		{`tags.env == "prod" && tags["cost-center"] == "42"`, map[string][]string{"tag:env": {"prod"}, "tag:cost-center": {"42"}}},
+		{`name == "web"`, map[string][]string{"tag:Name": {"web"}}},
+		{`name == "web-*"`, map[string][]string{}},
+		{`name == "web-?" && state == "running"`, map[string][]string{instance_state_name_key: {"running"}}},
+		{`tags.env == "prod*"`, map[string][]string{}},
+		{`name == "web" || name == "db*"`, map[string][]string{}},
+		{`name =~ "web-.*"`, map[string][]string{}},
+		{`state != "running"`, map[string][]string{}},
+		{`vcpus == 2 && platform == "windows"`, map[string][]string{}},
+		{`state == "running" || type == "t3.micro"`, map[string][]string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.where, func(t *testing.T) {
+			where, err := expr.Compile(test.where, InstanceSchema, time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string][]string{}
+			for _, filter := range pushdownFilters(where, instanceFilterNames) {
+				got[aws.StringValue(filter.Name)] = aws.StringValueSlice(filter.Values)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("pushdownFilters() = %v, want %v", got, test.want)
+			}
+		})
+	}
+	if filters := pushdownFilters(nil, instanceFilterNames); len(filters) != 0 {
+		t.Errorf("pushdownFilters(nil) = %v, want no filters", filters)
+	}
+}
@@ -0,0 +1,416 @@
+package s3
+
+import (
+	"bytes"
+	"cloudctl/provider/aws"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RESOURCE_POLICY     = "policy"
+	RESOURCE_LIFECYCLE  = "lifecycle"
+	RESOURCE_CORS       = "cors"
+	RESOURCE_TAGS       = "tags"
+	RESOURCE_VERSIONING = "versioning"
+	RESOURCE_ENCRYPTION = "encryption"
+)
+
+var (
+	// error codes returned by getters when bucket has no configuration of the resource
+	noSuchConfigurationCodes = map[string]bool{
+		"NoSuchBucketPolicy":                             true,
+		"NoSuchLifecycleConfiguration":                   true,
+		"NoSuchCORSConfiguration":                        true,
+		"NoSuchTagSet":                                   true,
+		"ServerSideEncryptionConfigurationNotFoundError": true,
+	}
+)
+
+// BucketConfigChange describe how to read current and build desired configuration of a bucket resource,
+// desired is nil when the configuration has to be deleted
+type BucketConfigChange struct {
+	resource string
+	action   string
+	current  func(bucketName string, client *aws.Client) (interface{}, error)
+	desired  func(current interface{}) (interface{}, error)
+	apply    func(bucketName string, client *aws.Client, desired interface{}) error
+}
+
+func NewPolicySetChange(file string) (*BucketConfigChange, error) {
+	var policy interface{}
+	if err := decodeConfigFile(file, &policy, false); err != nil {
+		return nil, err
+	}
+	if err := validatePolicy(policy); err != nil {
+		return nil, fmt.Errorf("invalid policy in %s, %w", file, err)
+	}
+	return &BucketConfigChange{
+		resource: RESOURCE_POLICY,
+		action:   "set",
+		current:  currentBucketPolicy,
+		desired:  staticDesired(policy),
+		apply: func(bucketName string, client *aws.Client, desired interface{}) error {
+			policy, _ := json.Marshal(desired)
+			_, err := client.S3.PutBucketPolicy(&s3.PutBucketPolicyInput{Bucket: &bucketName, Policy: awssdk.String(string(policy))})
+			return err
+		},
+	}, nil
+}
+
+func NewPolicyDeleteChange() *BucketConfigChange {
+	return &BucketConfigChange{
+		resource: RESOURCE_POLICY,
+		action:   "delete",
+		current:  currentBucketPolicy,
+		desired:  staticDesired(nil),
+		apply: func(bucketName string, client *aws.Client, _ interface{}) error {
+			_, err := client.S3.DeleteBucketPolicy(&s3.DeleteBucketPolicyInput{Bucket: &bucketName})
+			return err
+		},
+	}
+}
+
+func NewLifecycleSetChange(file string) (*BucketConfigChange, error) {
+	lifecycle := &s3.BucketLifecycleConfiguration{}
+	if err := decodeConfigFile(file, lifecycle, true); err != nil {
+		return nil, err
+	}
+	input := &s3.PutBucketLifecycleConfigurationInput{Bucket: awssdk.String("validate"), LifecycleConfiguration: lifecycle}
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid lifecycle configuration in %s, %w", file, err)
+	}
+	return &BucketConfigChange{
+		resource: RESOURCE_LIFECYCLE,
+		action:   "set",
+		current: func(bucketName string, client *aws.Client) (interface{}, error) {
+			definition := &bucketDefinition{}
+			res := getBucketLifecycleConfig(&bucketName, client, definition)
+			if res == nil {
+				return nil, ignoreNoSuchConfiguration(definition.lifeCycleAPIError)
+			}
+			return &s3.BucketLifecycleConfiguration{Rules: res.Rules}, nil
+		},
+		desired: staticDesired(lifecycle),
+		apply: func(bucketName string, client *aws.Client, desired interface{}) error {
+			_, err := client.S3.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+				Bucket:                 &bucketName,
+				LifecycleConfiguration: desired.(*s3.BucketLifecycleConfiguration),
+			})
+			return err
+		},
+	}, nil
+}
+
+func NewCorsSetChange(file string) (*BucketConfigChange, error) {
+	cors := &s3.CORSConfiguration{}
+	if err := decodeConfigFile(file, cors, true); err != nil {
+		return nil, err
+	}
+	input := &s3.PutBucketCorsInput{Bucket: awssdk.String("validate"), CORSConfiguration: cors}
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cors configuration in %s, %w", file, err)
+	}
+	return &BucketConfigChange{
+		resource: RESOURCE_CORS,
+		action:   "set",
+		current: func(bucketName string, client *aws.Client) (interface{}, error) {
+			definition := &bucketDefinition{}
+			res := getBucketCorsConfig(&bucketName, client, definition)
+			if res == nil {
+				return nil, ignoreNoSuchConfiguration(definition.corsAPIError)
+			}
+			return &s3.CORSConfiguration{CORSRules: res.CORSRules}, nil
+		},
+		desired: staticDesired(cors),
+		apply: func(bucketName string, client *aws.Client, desired interface{}) error {
+			_, err := client.S3.PutBucketCors(&s3.PutBucketCorsInput{
+				Bucket:            &bucketName,
+				CORSConfiguration: desired.(*s3.CORSConfiguration),
+			})
+			return err
+		},
+	}, nil
+}
+
+// NewTagsSetChange replace bucket tags with key/value map from file
+func NewTagsSetChange(file string) (*BucketConfigChange, error) {
+	tags := map[string]string{}
+	if err := decodeConfigFile(file, &tags, true); err != nil {
+		return nil, err
+	}
+	return newTagsChange("set", staticDesired(tags)), nil
+}
+
+func NewTagsAddChange(tags map[string]string) *BucketConfigChange {
+	return newTagsChange("add", func(current interface{}) (interface{}, error) {
+		desired := copyTags(current)
+		for k, v := range tags {
+			desired[k] = v
+		}
+		return desired, nil
+	})
+}
+
+func NewTagsRemoveChange(keys []string) *BucketConfigChange {
+	return newTagsChange("remove", func(current interface{}) (interface{}, error) {
+		desired := copyTags(current)
+		for _, k := range keys {
+			delete(desired, k)
+		}
+		return desired, nil
+	})
+}
+
+func NewVersioningChange(status string) *BucketConfigChange {
+	action := strings.ToLower(status)
+	if status == s3.BucketVersioningStatusEnabled {
+		action = "enable"
+	} else if status == s3.BucketVersioningStatusSuspended {
+		action = "suspend"
+	}
+	return &BucketConfigChange{
+		resource: RESOURCE_VERSIONING,
+		action:   action,
+		current: func(bucketName string, client *aws.Client) (interface{}, error) {
+			definition := &bucketDefinition{}
+			res := getBucketVersionConfig(&bucketName, client, definition)
+			if res == nil {
+				return nil, definition.versionAPIErr
+			}
+			if res.Status == nil {
+				return nil, nil
+			}
+			return &s3.VersioningConfiguration{Status: res.Status}, nil
+		},
+		desired: staticDesired(&s3.VersioningConfiguration{Status: &status}),
+		apply: func(bucketName string, client *aws.Client, desired interface{}) error {
+			_, err := client.S3.PutBucketVersioning(&s3.PutBucketVersioningInput{
+				Bucket:                  &bucketName,
+				VersioningConfiguration: desired.(*s3.VersioningConfiguration),
+			})
+			return err
+		},
+	}
+}
+
+func NewEncryptionSetChange(file string) (*BucketConfigChange, error) {
+	encryption := &s3.ServerSideEncryptionConfiguration{}
+	if err := decodeConfigFile(file, encryption, true); err != nil {
+		return nil, err
+	}
+	input := &s3.PutBucketEncryptionInput{Bucket: awssdk.String("validate"), ServerSideEncryptionConfiguration: encryption}
+	if err := input.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid encryption configuration in %s, %w", file, err)
+	}
+	return &BucketConfigChange{
+		resource: RESOURCE_ENCRYPTION,
+		action:   "set",
+		current: func(bucketName string, client *aws.Client) (interface{}, error) {
+			definition := &bucketDefinition{}
+			res := getBucketencryptionConfig(&bucketName, client, definition)
+			if res == nil {
+				return nil, ignoreNoSuchConfiguration(definition.encryptionConfigAPIError)
+			}
+			return res.ServerSideEncryptionConfiguration, nil
+		},
+		desired: staticDesired(encryption),
+		apply: func(bucketName string, client *aws.Client, desired interface{}) error {
+			_, err := client.S3.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+				Bucket:                            &bucketName,
+				ServerSideEncryptionConfiguration: desired.(*s3.ServerSideEncryptionConfiguration),
+			})
+			return err
+		},
+	}, nil
+}
+
+func newTagsChange(action string, desired func(current interface{}) (interface{}, error)) *BucketConfigChange {
+	return &BucketConfigChange{
+		resource: RESOURCE_TAGS,
+		action:   action,
+		current: func(bucketName string, client *aws.Client) (interface{}, error) {
+			definition := &bucketDefinition{}
+			res := getBucketTags(&bucketName, client, definition)
+			if res == nil {
+				return map[string]string{}, ignoreNoSuchConfiguration(definition.tagsAPIError)
+			}
+			tags := map[string]string{}
+			for _, tag := range res.TagSet {
+				tags[*tag.Key] = *tag.Value
+			}
+			return tags, nil
+		},
+		desired: desired,
+		apply: func(bucketName string, client *aws.Client, desired interface{}) error {
+			tags := desired.(map[string]string)
+			if len(tags) == 0 {
+				_, err := client.S3.DeleteBucketTagging(&s3.DeleteBucketTaggingInput{Bucket: &bucketName})
+				return err
+			}
+			keys := make([]string, 0, len(tags))
+			for k := range tags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			tagSet := []*s3.Tag{}
+			for _, k := range keys {
+				tagSet = append(tagSet, &s3.Tag{Key: awssdk.String(k), Value: awssdk.String(tags[k])})
+			}
+			_, err := client.S3.PutBucketTagging(&s3.PutBucketTaggingInput{
+				Bucket:  &bucketName,
+				Tagging: &s3.Tagging{TagSet: tagSet},
+			})
+			return err
+		},
+	}
+}
+
+func currentBucketPolicy(bucketName string, client *aws.Client) (interface{}, error) {
+	definition := &bucketDefinition{}
+	res := getBucketPolicy(&bucketName, client, definition)
+	if res == nil {
+		return nil, ignoreNoSuchConfiguration(definition.policyAPIErr)
+	}
+	var policy interface{}
+	if err := json.Unmarshal([]byte(awssdk.StringValue(res.Policy)), &policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// validatePolicy check structure of bucket policy document, semantics are left to PutBucketPolicy
+func validatePolicy(policy interface{}) error {
+	document, ok := policy.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected a policy document object")
+	}
+	if version, ok := document["Version"].(string); !ok || (version != "2012-10-17" && version != "2008-10-17") {
+		return fmt.Errorf("policy Version must be 2012-10-17 or 2008-10-17")
+	}
+	statements, ok := document["Statement"].([]interface{})
+	if statement, isObject := document["Statement"].(map[string]interface{}); isObject {
+		statements, ok = []interface{}{statement}, true
+	}
+	if !ok || len(statements) == 0 {
+		return fmt.Errorf("policy Statement must be a non empty list of statements")
+	}
+	for i, s := range statements {
+		statement, ok := s.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("statement %d must be an object", i+1)
+		}
+		if effect := statement["Effect"]; effect != "Allow" && effect != "Deny" {
+			return fmt.Errorf("statement %d Effect must be Allow or Deny", i+1)
+		}
+		for _, keys := range [][]string{{"Principal", "NotPrincipal"}, {"Action", "NotAction"}, {"Resource", "NotResource"}} {
+			if statement[keys[0]] == nil && statement[keys[1]] == nil {
+				return fmt.Errorf("statement %d must have %s or %s", i+1, keys[0], keys[1])
+			}
+		}
+	}
+	return nil
+}
+
+func staticDesired(desired interface{}) func(interface{}) (interface{}, error) {
+	return func(interface{}) (interface{}, error) {
+		return desired, nil
+	}
+}
+
+func copyTags(current interface{}) map[string]string {
+	tags := map[string]string{}
+	if current != nil {
+		for k, v := range current.(map[string]string) {
+			tags[k] = v
+		}
+	}
+	return tags
+}
+
+func ignoreNoSuchConfiguration(err error) error {
+	if awsErr, ok := err.(awserr.Error); ok && noSuchConfigurationCodes[awsErr.Code()] {
+		return nil
+	}
+	return err
+}
+
+// decodeConfigFile decode JSON or YAML(.yaml/.yml) file into v, strict mode rejects unknown fields
+func decodeConfigFile(file string, v interface{}, strict bool) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	ext := strings.ToLower(filepath.Ext(file))
+	if ext == ".yaml" || ext == ".yml" {
+		var data interface{}
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return fmt.Errorf("invalid yaml in %s, %w", file, err)
+		}
+		if content, err = json.Marshal(data); err != nil {
+			return fmt.Errorf("invalid yaml in %s, %w", file, err)
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid configuration in %s, %w", file, err)
+	}
+	return nil
+}
+
+// renderConfig render configuration as indented JSON without empty fields, keys are sorted for a stable diff
+func renderConfig(config interface{}) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+	content, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	var data interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return "", err
+	}
+	data = pruneEmpty(data)
+	if data == nil {
+		return "", nil
+	}
+	content, err = json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func pruneEmpty(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for k, v := range value {
+			if pruned := pruneEmpty(v); pruned == nil {
+				delete(value, k)
+			} else {
+				value[k] = pruned
+			}
+		}
+		if len(value) == 0 {
+			return nil
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = pruneEmpty(v)
+		}
+	}
+	return data
+}
@@ -1,6 +1,9 @@
 package s3
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 func NoBucketFound() error {
 	return fmt.Errorf("no bucket found")
@@ -14,3 +17,24 @@ func NoObjectFoundWithGivenPrefix(bucketname, prefix string) error {
 func BucketContainMoreObject(bucketName string, maxKeys int64) error {
 	return fmt.Errorf("%s contains more objects than the maximum key limit of %d", bucketName, maxKeys)
 }
+func ObjectArchived(key, storageClass string) error {
+	return fmt.Errorf("%s is archived in %s storage class, restore it with `s3 restore` or download with --wait-restore", key, storageClass)
+}
+func ObjectRestoreNotRequested(key string) error {
+	return fmt.Errorf("restore is not requested for archived object %s, request it with `s3 restore`", key)
+}
+func RestoreWaitTimeout(key string, maxWait time.Duration) error {
+	return fmt.Errorf("restore of %s didn't complete within %s, download it later or increase --max-wait", key, maxWait)
+}
+func NoRestoreTargetProvided() error {
+	return fmt.Errorf("either --key or --prefix must be provided")
+}
+func NoConfigurationChange(bucketName, resource string) error {
+	return fmt.Errorf("%s of %s is already up to date", resource, bucketName)
+}
+func ChangeNotConfirmed(bucketName, resource string) error {
+	return fmt.Errorf("%s change of %s is not applied", resource, bucketName)
+}
+func BucketDeleteNotConfirmed(bucketName string) error {
+	return fmt.Errorf("confirmation doesn't match, %s is not deleted", bucketName)
+}
@@ -4,18 +4,29 @@ import (
 	"cloudctl/executor"
 	"cloudctl/provider/aws"
 	"cloudctl/provider/aws/cli/globals"
+	"time"
 
 	ctltime "cloudctl/time"
 )
 
 const (
 	DATE_PASER = "2006-01-02 15:04:05"
+
+	RESTORE_POLL_INTERVAL = time.Minute
+	// concurrent HeadObject requests for restore status of archived objects
+	RESTORE_STATUS_WORKERS = 10
+	// concurrent GetBucketLocation requests when listing buckets
+	BUCKET_REGION_WORKERS = 10
+	// concurrent RestoreObject requests
+	RESTORE_OBJECT_WORKERS = 10
+	// ListObjects max page size, used when objects are filtered client side
+	LIST_OBJECTS_PAGE_SIZE = 1000
 )
 
 func NewBucketListCommandExecutor(flag *globals.CLIFlag, filter *BucketListFilter) *executor.CommandExecutor {
 	tz := ctltime.GetTZ(flag.TZShortIdentifier)
 
-	client := aws.NewClient(flag.Profile, flag.Region, flag.Debug)
+	client := aws.NewClient(flag)
 
 	return &executor.CommandExecutor{
 		Fetcher: &bucketListFetcher{
@@ -27,8 +38,8 @@ func NewBucketListCommandExecutor(flag *globals.CLIFlag, filter *BucketListFilte
 	}
 }
 
-func NewBucketObjectListCommandExecutor(flag *globals.CLIFlag, bucketName string, bucketPrefix *string, maxKeys int64) *executor.CommandExecutor {
-	client := aws.NewClient(flag.Profile, flag.Region, flag.Debug)
+func NewBucketObjectListCommandExecutor(flag *globals.CLIFlag, bucketName string, bucketPrefix *string, maxKeys int64, filter *BucketObjectListFilter) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
 
 	return &executor.CommandExecutor{
 		Fetcher: &bucketObjectsFetcher{
@@ -36,6 +47,7 @@ func NewBucketObjectListCommandExecutor(flag *globals.CLIFlag, bucketName string
 			bucketName:   bucketName,
 			objectPrefix: bucketPrefix,
 			maxKeys:      maxKeys,
+			filter:       filter,
 			tz:           ctltime.GetTZ(flag.TZShortIdentifier),
 		},
 		Viewer: bucketObjectsViewer,
@@ -43,7 +55,7 @@ func NewBucketObjectListCommandExecutor(flag *globals.CLIFlag, bucketName string
 }
 
 func NewBucketViewCommandExecutor(flag *globals.CLIFlag, bucketName string) *executor.CommandExecutor {
-	client := aws.NewClient(flag.Profile, flag.Region, flag.Debug)
+	client := aws.NewClient(flag)
 
 	return &executor.CommandExecutor{
 		Fetcher: &bucketConfigurationFetcher{
@@ -54,18 +66,85 @@ func NewBucketViewCommandExecutor(flag *globals.CLIFlag, bucketName string) *exe
 	}
 }
 
-func NewBucketObjectDownloadCommandExecutor(flag *globals.CLIFlag, bucketName, key, path string, recursive bool) *executor.CommandExecutor {
+func NewBucketObjectDownloadCommandExecutor(flag *globals.CLIFlag, bucketName, key, path string, recursive, waitRestore bool, maxWait time.Duration) *executor.CommandExecutor {
 
-	client := aws.NewClient(flag.Profile, flag.Region, flag.Debug)
+	client := aws.NewClient(flag)
 
 	return &executor.CommandExecutor{
 		Fetcher: &bucketObjectsDownloadFetcher{
+			client:      client,
+			bucketName:  bucketName,
+			key:         key,
+			path:        path,
+			recursive:   recursive,
+			waitRestore: waitRestore,
+			maxWait:     maxWait,
+		},
+		Viewer: bucketObjectsDownloadSummaryViewer,
+	}
+}
+
+func NewBucketObjectRestoreCommandExecutor(flag *globals.CLIFlag, bucketName string, key, prefix *string, maxKeys, days int64, tier string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+
+	return &executor.CommandExecutor{
+		Fetcher: &bucketObjectsRestoreFetcher{
 			client:     client,
 			bucketName: bucketName,
 			key:        key,
-			path:       path,
-			recursive:  recursive,
+			prefix:     prefix,
+			maxKeys:    maxKeys,
+			days:       days,
+			tier:       tier,
 		},
-		Viewer: bucketObjectsDownloadSummaryViewer,
+		Viewer: bucketObjectsRestoreSummaryViewer,
+	}
+}
+
+func NewBucketConfigChangeCommandExecutor(flag *globals.CLIFlag, bucketName string, change *BucketConfigChange, autoApprove bool) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+
+	return &executor.CommandExecutor{
+		Fetcher: &bucketConfigChangeFetcher{
+			client:     client,
+			bucketName: bucketName,
+			change:     change,
+		},
+		Viewer:     bucketConfigChangeViewer,
+		PlanViewer: bucketConfigChangePlanViewer,
+		Confirm:    aws.Confirmer(autoApprove),
+	}
+}
+
+func NewBucketCreateCommandExecutor(flag *globals.CLIFlag, bucketName string, versioning bool, encryption, kmsKeyId *string, blockPublicAccess bool, tags map[string]string) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+
+	return &executor.CommandExecutor{
+		Fetcher: &bucketCreateFetcher{
+			client:            client,
+			bucketName:        bucketName,
+			versioning:        versioning,
+			encryption:        encryption,
+			kmsKeyId:          kmsKeyId,
+			blockPublicAccess: blockPublicAccess,
+			tags:              tags,
+		},
+		Viewer: bucketOperationSummaryViewer,
+	}
+}
+
+// NewBucketDeleteCommandExecutor bucket name is asked as confirmation with force, empty bucket is deleted without confirmation
+func NewBucketDeleteCommandExecutor(flag *globals.CLIFlag, bucketName string, force, autoApprove bool) *executor.CommandExecutor {
+	client := aws.NewClient(flag)
+
+	return &executor.CommandExecutor{
+		Fetcher: &bucketDeleteFetcher{
+			client:     client,
+			bucketName: bucketName,
+			force:      force,
+		},
+		Viewer:     bucketOperationSummaryViewer,
+		PlanViewer: bucketDeletePlanViewer,
+		Confirm:    aws.InputConfirmer(!force || autoApprove, bucketName),
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
@@ -27,6 +28,7 @@ type bucketObjectsFetcher struct {
 	bucketName   string
 	objectPrefix *string
 	maxKeys      int64
+	filter       *BucketObjectListFilter
 	tz           *itime.Timezone
 }
 
@@ -36,11 +38,47 @@ type bucketConfigurationFetcher struct {
 	bucketName string
 }
 type bucketObjectsDownloadFetcher struct {
+	client      *aws.Client
+	bucketName  string
+	key         string
+	path        string
+	recursive   bool
+	waitRestore bool
+	maxWait     time.Duration
+}
+
+type bucketConfigChangeFetcher struct {
+	client     *aws.Client
+	bucketName string
+	change     *BucketConfigChange
+}
+
+type bucketCreateFetcher struct {
+	client            *aws.Client
+	bucketName        string
+	versioning        bool
+	encryption        *string
+	kmsKeyId          *string
+	blockPublicAccess bool
+	tags              map[string]string
+}
+
+type bucketDeleteFetcher struct {
 	client     *aws.Client
 	bucketName string
-	key        string
-	path       string
-	recursive  bool
+	// delete all objects including versions before delete bucket
+	force bool
+}
+
+type bucketObjectsRestoreFetcher struct {
+	client     *aws.Client
+	bucketName string
+	// restore single object by key or all archived objects with prefix
+	key     *string
+	prefix  *string
+	maxKeys int64
+	days    int64
+	tier    string
 }
 
 func (f bucketListFetcher) Fetch() interface{} {
@@ -60,6 +98,24 @@ func (f bucketListFetcher) Fetch() interface{} {
 			buckets = append(buckets, newBucketOutput(o, f.tz))
 		}
 	}
+	jobs := make(chan *bucketOutput)
+	wg := new(sync.WaitGroup)
+	for i := 0; i < BUCKET_REGION_WORKERS && i < len(buckets); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bucket := range jobs {
+				if region, err := bucketRegion(*bucket.name, f.client); err == nil {
+					bucket.region = &region
+				}
+			}
+		}()
+	}
+	for _, bucket := range buckets {
+		jobs <- bucket
+	}
+	close(jobs)
+	wg.Wait()
 	// default sort(asc) by creation darte
 	sort.Slice(buckets, func(i, j int) bool {
 		return buckets[i].creationDate.Before(*buckets[j].creationDate)
@@ -69,12 +125,20 @@ func (f bucketListFetcher) Fetch() interface{} {
 
 func (f bucketObjectsFetcher) Fetch() interface{} {
 	output := []*bucketObjectOutput{}
+	client := bucketClient(f.bucketName, f.client)
 
-	objectsPtr, errInfo := fetchBucketObjects(f.bucketName, f.objectPrefix, f.maxKeys, f.client)
+	objectsPtr, errInfo := fetchBucketObjects(f.bucketName, f.objectPrefix, f.maxKeys, f.filter.applyCustomFilter, client)
 
+	archived := []*bucketObjectOutput{}
 	for _, o := range *objectsPtr {
-		output = append(output, newBucketObjectOutput(o, f.tz))
+		object := newBucketObjectOutput(o, f.tz)
+		if isArchivedStorageClass(o.StorageClass) {
+			archived = append(archived, object)
+		}
+		output = append(output, object)
 	}
+	setRestoreStatus(f.bucketName, archived, client)
+	f.filter.sort(output)
 	if errInfo != nil {
 		return &bucketObjectListOutput{bucketName: &f.bucketName, objects: output, err: errInfo}
 	}
@@ -89,45 +153,53 @@ func (f bucketConfigurationFetcher) Fetch() interface{} {
 
 	definition := &bucketDefinition{}
 	definition.SetBucketName(f.bucketName)
+	client := bucketClient(f.bucketName, f.client)
 
 	wg := new(sync.WaitGroup)
-	wg.Add(5)
+	wg.Add(6)
 
 	go func() {
 		defer wg.Done()
-		data := getBucketPolicy(&f.bucketName, f.client, definition)
+		data := getBucketPolicy(&f.bucketName, client, definition)
 		if data != nil {
 			definition.SetPolicy(data)
 		}
 	}()
 	go func() {
 		defer wg.Done()
-		data := getBucketVersionConfig(&f.bucketName, f.client, definition)
+		data := getBucketVersionConfig(&f.bucketName, client, definition)
 		if data != nil {
 			definition.SetVersion(data)
 		}
 	}()
 	go func() {
 		defer wg.Done()
-		data := getBucketTags(&f.bucketName, f.client, definition)
+		data := getBucketTags(&f.bucketName, client, definition)
 		if data != nil {
 			definition.SetTags(data)
 		}
 	}()
 	go func() {
 		defer wg.Done()
-		data := getBucketencryptionConfig(&f.bucketName, f.client, definition)
+		data := getBucketencryptionConfig(&f.bucketName, client, definition)
 		if data != nil {
 			definition.SetEncryptionConfig(data)
 		}
 	}()
 	go func() {
 		defer wg.Done()
-		data := getBucketLifecycleConfig(&f.bucketName, f.client, definition)
+		data := getBucketLifecycleConfig(&f.bucketName, client, definition)
 		if data != nil {
 			definition.SetLifeCycle(data)
 		}
 	}()
+	go func() {
+		defer wg.Done()
+		data := getBucketCorsConfig(&f.bucketName, client, definition)
+		if data != nil {
+			definition.SetCors(data)
+		}
+	}()
 	wg.Wait()
 	return definition
 }
@@ -136,11 +208,12 @@ func (f bucketObjectsDownloadFetcher) Fetch() interface{} {
 	objectDownloadSummaryChan := make(chan *objectDownloadSummary)
 	objectsDownloadSummary := []*objectDownloadSummary{}
 	defer close(objectDownloadSummaryChan)
+	client := bucketClient(f.bucketName, f.client)
 	if f.recursive {
 		input := &s3.ListObjectsInput{}
 		input.Bucket = &f.bucketName
 		input.Prefix = &f.key
-		apiOutput, err := f.client.S3.ListObjects(input)
+		apiOutput, err := client.S3.ListObjects(input)
 		if err != nil {
 			return &bucketOjectsDownloadSummary{err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
 		}
@@ -148,23 +221,75 @@ func (f bucketObjectsDownloadFetcher) Fetch() interface{} {
 			return &bucketOjectsDownloadSummary{err: aws.NewErrorInfo(NoObjectFoundWithGivenPrefix(f.bucketName, f.key), viewer.WARN, nil)}
 		}
 		for _, object := range apiOutput.Contents {
-			go downloadObject(f.bucketName, *object.Key, f.path, f.client, objectDownloadSummaryChan)
+			go downloadObject(f.bucketName, *object.Key, object.StorageClass, f.path, f.waitRestore, f.maxWait, client, objectDownloadSummaryChan)
 		}
 		for i := 0; i < len(apiOutput.Contents); i++ {
 			objectsDownloadSummary = append(objectsDownloadSummary, <-objectDownloadSummaryChan)
 		}
 		return &bucketOjectsDownloadSummary{bucketName: f.bucketName, objectsDownloadSummary: objectsDownloadSummary, err: nil}
 	}
-	go downloadObject(f.bucketName, f.key, f.path, f.client, objectDownloadSummaryChan)
+	go downloadObject(f.bucketName, f.key, nil, f.path, f.waitRestore, f.maxWait, client, objectDownloadSummaryChan)
 	objectsDownloadSummary = append(objectsDownloadSummary, <-objectDownloadSummaryChan)
 	return &bucketOjectsDownloadSummary{bucketName: f.bucketName, objectsDownloadSummary: objectsDownloadSummary, err: nil}
 }
 
-func fetchBucketObjects(bucketName string, objectPrefix *string, maxKeys int64, client *aws.Client) (*[]*s3.Object, *aws.ErrorInfo) {
+func (f bucketObjectsRestoreFetcher) Fetch() interface{} {
+	summary := &bucketObjectsRestoreSummary{bucketName: f.bucketName, days: f.days, tier: f.tier}
+	client := bucketClient(f.bucketName, f.client)
 
-	var fetch func(bucketName string, objectPrefix *string, remainingKeys int64, objectsPtr *[]*s3.Object, marker *string, client *aws.Client) *aws.ErrorInfo
+	objects := []*s3.Object{}
+	if f.key != nil {
+		apiOutput, err := client.S3.HeadObject(&s3.HeadObjectInput{Bucket: &f.bucketName, Key: f.key})
+		if err != nil {
+			summary.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+			return summary
+		}
+		objects = append(objects, &s3.Object{Key: f.key, StorageClass: apiOutput.StorageClass})
+	} else {
+		objectsPtr, errInfo := fetchBucketObjects(f.bucketName, f.prefix, f.maxKeys, nil, client)
+		if errInfo != nil && errInfo.ErrorType == viewer.ERROR {
+			summary.err = errInfo
+			return summary
+		}
+		objects = *objectsPtr
+	}
+	if len(objects) == 0 {
+		summary.err = aws.NewErrorInfo(NoObjectFoundWithGivenPrefix(f.bucketName, *f.prefix), viewer.WARN, nil)
+		return summary
+	}
 
-	fetch = func(bucketName string, objectPrefix *string, remainingKeys int64, objectsPtr *[]*s3.Object, marker *string, client *aws.Client) *aws.ErrorInfo {
+	summaryChan := make(chan *objectRestoreSummary)
+	defer close(summaryChan)
+	jobs := make(chan *s3.Object)
+	for i := 0; i < RESTORE_OBJECT_WORKERS && i < len(objects); i++ {
+		go func() {
+			for object := range jobs {
+				restoreObject(f.bucketName, object, f.days, f.tier, client, summaryChan)
+			}
+		}()
+	}
+	// jobs are queued aside, workers block on summaryChan until summaries are collected
+	go func() {
+		for _, object := range objects {
+			jobs <- object
+		}
+		close(jobs)
+	}()
+	for i := 0; i < len(objects); i++ {
+		summary.objectsSummary = append(summary.objectsSummary, <-summaryChan)
+	}
+	sort.Slice(summary.objectsSummary, func(i, j int) bool {
+		return summary.objectsSummary[i].key < summary.objectsSummary[j].key
+	})
+	return summary
+}
+
+// fetchBucketObjects keep listing until maxKeys objects match, match is optional
+func fetchBucketObjects(bucketName string, objectPrefix *string, maxKeys int64, match func(*s3.Object) bool, client *aws.Client) (*[]*s3.Object, *aws.ErrorInfo) {
+
+	var fetch func(bucketName string, objectPrefix *string, remainingKeys int64, fullPage bool, objectsPtr *[]*s3.Object, marker *string, client *aws.Client) *aws.ErrorInfo
+
+	fetch = func(bucketName string, objectPrefix *string, remainingKeys int64, fullPage bool, objectsPtr *[]*s3.Object, marker *string, client *aws.Client) *aws.ErrorInfo {
 		if remainingKeys == 0 { // terminate condition
 			if marker != nil {
 				return aws.NewErrorInfo(BucketContainMoreObject(bucketName, maxKeys), viewer.INFO, nil)
@@ -176,6 +301,9 @@ func fetchBucketObjects(bucketName string, objectPrefix *string, maxKeys int64,
 		input.Bucket = &bucketName
 		input.Prefix = objectPrefix
 		input.MaxKeys = &remainingKeys
+		if fullPage {
+			input.MaxKeys = awssdk.Int64(LIST_OBJECTS_PAGE_SIZE)
+		}
 		input.Marker = marker
 
 		apiOutput, err := client.S3.ListObjects(input)
@@ -183,22 +311,39 @@ func fetchBucketObjects(bucketName string, objectPrefix *string, maxKeys int64,
 			return aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
 		}
 		apiOutputLen := len(apiOutput.Contents)
-		*objectsPtr = append(*objectsPtr, apiOutput.Contents...)
+		for i, object := range apiOutput.Contents {
+			if match != nil && !match(object) {
+				continue
+			}
+			if remainingKeys == 0 {
+				// more matches in current page
+				return fetch(bucketName, objectPrefix, 0, fullPage, objectsPtr, apiOutput.Contents[i-1].Key, client)
+			}
+			*objectsPtr = append(*objectsPtr, object)
+			remainingKeys--
+		}
 		if *apiOutput.IsTruncated {
 			marker = apiOutput.Contents[apiOutputLen-1].Key
-			remainingKeys := remainingKeys - int64(apiOutputLen)
-			return fetch(bucketName, objectPrefix, remainingKeys, objectsPtr, marker, client)
+			// page came back short of matches, list full pages from now on
+			return fetch(bucketName, objectPrefix, remainingKeys, fullPage || match != nil, objectsPtr, marker, client)
 		}
 		return nil
 	}
 	objects := []*s3.Object{}
 	nextMarker := "" // empty marker to start process
-	err := fetch(bucketName, objectPrefix, maxKeys, &objects, &nextMarker, client)
+	err := fetch(bucketName, objectPrefix, maxKeys, false, &objects, &nextMarker, client)
 	return &objects, err
 }
 
-func downloadObject(bucketName, key, path string, client *aws.Client, downloadSummaryChan chan<- *objectDownloadSummary) {
+// downloadObject storageClass is nil when object isn't listed, restore is checked only for archived object or with waitRestore
+func downloadObject(bucketName, key string, storageClass *string, path string, waitRestore bool, maxWait time.Duration, client *aws.Client, downloadSummaryChan chan<- *objectDownloadSummary) {
 	start := time.Now()
+	if waitRestore || isArchivedStorageClass(storageClass) {
+		if errInfo := ensureObjectRestored(bucketName, key, waitRestore, maxWait, client); errInfo != nil {
+			downloadSummaryChan <- newBucketObjectDownloadSummary(key, "", 0, time.Since(start), errInfo)
+			return
+		}
+	}
 	downloadFileAbsPath := fmt.Sprintf("%s/%s", path, key)
 
 	fileDir := filepath.Dir(downloadFileAbsPath)
@@ -219,7 +364,10 @@ func downloadObject(bucketName, key, path string, client *aws.Client, downloadSu
 			Bucket: &bucketName,
 			Key:    &key,
 		})
-		if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "InvalidObjectState" {
+			// object wasn't checked before download, archived object isn't readable
+			downloadSummaryChan <- newBucketObjectDownloadSummary(key, "", 0, time.Since(start), aws.NewErrorInfo(ObjectArchived(key, "GLACIER/DEEP_ARCHIVE"), viewer.ERROR, nil))
+		} else if err != nil {
 			downloadSummaryChan <- newBucketObjectDownloadSummary(key, "", 0, time.Since(start), aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil))
 		} else {
 			downloadSummaryChan <- newBucketObjectDownloadSummary(key, file.Name(), numBytesWrite, time.Since(start), nil)
@@ -227,11 +375,289 @@ func downloadObject(bucketName, key, path string, client *aws.Client, downloadSu
 	}
 }
 
+func (f bucketConfigChangeFetcher) Fetch() interface{} {
+	output := &bucketConfigChangeOutput{bucketName: f.bucketName, resource: f.change.resource, action: f.change.action}
+	client := bucketClient(f.bucketName, f.client)
+
+	current, err := f.change.current(f.bucketName, client)
+	if err != nil {
+		output.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+		return output
+	}
+	desired, err := f.change.desired(current)
+	if err != nil {
+		output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+		return output
+	}
+	if output.currentConfig, err = renderConfig(current); err != nil {
+		output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+		return output
+	}
+	if output.desiredConfig, err = renderConfig(desired); err != nil {
+		output.err = aws.NewErrorInfo(err, viewer.ERROR, nil)
+		return output
+	}
+	if output.currentConfig == output.desiredConfig {
+		output.err = aws.NewErrorInfo(NoConfigurationChange(f.bucketName, f.change.resource), viewer.INFO, nil)
+		return output
+	}
+	output.desired = desired
+	return output
+}
+
+func (f bucketConfigChangeFetcher) Confirmation(plan interface{}) string {
+	if plan.(*bucketConfigChangeOutput).err != nil {
+		return ""
+	}
+	return fmt.Sprintf("Apply %s change to %s?", f.change.resource, f.bucketName)
+}
+
+func (f bucketConfigChangeFetcher) Apply(plan interface{}, confirmed bool) interface{} {
+	output := plan.(*bucketConfigChangeOutput)
+	if !confirmed {
+		output.err = aws.NewErrorInfo(ChangeNotConfirmed(f.bucketName, f.change.resource), viewer.WARN, nil)
+		return output
+	}
+	if err := f.change.apply(f.bucketName, bucketClient(f.bucketName, f.client), output.desired); err != nil {
+		output.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+	}
+	return output
+}
+
+func (f bucketCreateFetcher) Fetch() interface{} {
+	summary := &bucketOperationSummary{bucketName: f.bucketName, operation: "Create"}
+	client := f.client
+
+	input := &s3.CreateBucketInput{Bucket: &f.bucketName}
+	// us-east-1 is the default location & can't be used as location constraint
+	if region := client.Region(); region != "us-east-1" {
+		input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{LocationConstraint: &region}
+	}
+	_, err := client.S3.CreateBucket(input)
+	if !summary.addStep(fmt.Sprintf("create bucket in %s", client.Region()), err) {
+		return summary
+	}
+	if f.blockPublicAccess {
+		_, err := client.S3.PutPublicAccessBlock(&s3.PutPublicAccessBlockInput{
+			Bucket: &f.bucketName,
+			PublicAccessBlockConfiguration: &s3.PublicAccessBlockConfiguration{
+				BlockPublicAcls:       awssdk.Bool(true),
+				BlockPublicPolicy:     awssdk.Bool(true),
+				IgnorePublicAcls:      awssdk.Bool(true),
+				RestrictPublicBuckets: awssdk.Bool(true),
+			},
+		})
+		summary.addStep("block public access", err)
+	}
+	if f.versioning {
+		_, err := client.S3.PutBucketVersioning(&s3.PutBucketVersioningInput{
+			Bucket:                  &f.bucketName,
+			VersioningConfiguration: &s3.VersioningConfiguration{Status: awssdk.String(s3.BucketVersioningStatusEnabled)},
+		})
+		summary.addStep("enable versioning", err)
+	}
+	if f.encryption != nil {
+		_, err := client.S3.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+			Bucket: &f.bucketName,
+			ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+				Rules: []*s3.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+						SSEAlgorithm:   f.encryption,
+						KMSMasterKeyID: f.kmsKeyId,
+					},
+				}},
+			},
+		})
+		summary.addStep(fmt.Sprintf("set default encryption %s", *f.encryption), err)
+	}
+	if len(f.tags) != 0 {
+		err := newTagsChange("set", nil).apply(f.bucketName, client, f.tags)
+		summary.addStep(fmt.Sprintf("set %d tag(s)", len(f.tags)), err)
+	}
+	return summary
+}
+
+// Fetch count content of bucket to be deleted with --force, bucket is emptied & deleted by Apply
+func (f bucketDeleteFetcher) Fetch() interface{} {
+	plan := &bucketDeletePlan{bucketName: f.bucketName}
+	if !f.force {
+		return plan
+	}
+	content, err := fetchBucketContentSummary(f.bucketName, bucketClient(f.bucketName, f.client))
+	if err != nil {
+		return &bucketOperationSummary{bucketName: f.bucketName, operation: "Delete", err: aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)}
+	}
+	plan.content = content
+	return plan
+}
+
+func (f bucketDeleteFetcher) Confirmation(plan interface{}) string {
+	if _, ok := plan.(*bucketDeletePlan); !ok {
+		return ""
+	}
+	return fmt.Sprintf("Type the bucket name (%s) to permanently delete it with all content:", f.bucketName)
+}
+
+func (f bucketDeleteFetcher) Apply(plan interface{}, confirmed bool) interface{} {
+	content := plan.(*bucketDeletePlan).content
+	summary := &bucketOperationSummary{bucketName: f.bucketName, operation: "Delete"}
+	if !confirmed {
+		summary.err = aws.NewErrorInfo(BucketDeleteNotConfirmed(f.bucketName), viewer.WARN, nil)
+		return summary
+	}
+	client := bucketClient(f.bucketName, f.client)
+	if content != nil {
+		deleted, err := emptyBucket(f.bucketName, client)
+		if !summary.addStep(fmt.Sprintf("delete %d object version(s)", deleted), err) {
+			return summary
+		}
+	}
+	_, err := client.S3.DeleteBucket(&s3.DeleteBucketInput{Bucket: &f.bucketName})
+	summary.addStep("delete bucket", err)
+	return summary
+}
+
+// fetchBucketContentSummary count all object versions and delete markers of bucket
+func fetchBucketContentSummary(bucketName string, client *aws.Client) (*bucketContentSummary, error) {
+	summary := &bucketContentSummary{}
+	err := client.S3.ListObjectVersionsPages(&s3.ListObjectVersionsInput{Bucket: &bucketName}, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		for _, version := range page.Versions {
+			if awssdk.BoolValue(version.IsLatest) {
+				summary.objects++
+			}
+			summary.versions++
+			summary.sizeInBytes += awssdk.Int64Value(version.Size)
+		}
+		summary.deleteMarkers += int64(len(page.DeleteMarkers))
+		return true
+	})
+	return summary, err
+}
+
+// emptyBucket delete object versions & delete markers page by page, a page has at most 1000 keys which is the
+// DeleteObjects limit, so identifiers of whole bucket are never held in memory
+func emptyBucket(bucketName string, client *aws.Client) (int64, error) {
+	var deleted int64
+	var deleteErr error
+	err := client.S3.ListObjectVersionsPages(&s3.ListObjectVersionsInput{Bucket: &bucketName}, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		identifiers := []*s3.ObjectIdentifier{}
+		for _, version := range page.Versions {
+			identifiers = append(identifiers, &s3.ObjectIdentifier{Key: version.Key, VersionId: version.VersionId})
+		}
+		for _, marker := range page.DeleteMarkers {
+			identifiers = append(identifiers, &s3.ObjectIdentifier{Key: marker.Key, VersionId: marker.VersionId})
+		}
+		if len(identifiers) == 0 {
+			return true
+		}
+		apiOutput, err := client.S3.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: &bucketName,
+			Delete: &s3.Delete{Objects: identifiers, Quiet: awssdk.Bool(true)},
+		})
+		if err != nil {
+			deleteErr = err
+			return false
+		}
+		if len(apiOutput.Errors) != 0 {
+			failure := apiOutput.Errors[0]
+			deleteErr = fmt.Errorf("failed to delete %d object(s), first error on %s: %s", len(apiOutput.Errors), awssdk.StringValue(failure.Key), awssdk.StringValue(failure.Message))
+			return false
+		}
+		deleted += int64(len(identifiers))
+		return true
+	})
+	if deleteErr != nil {
+		return deleted, deleteErr
+	}
+	return deleted, err
+}
+
+func restoreObject(bucketName string, object *s3.Object, days int64, tier string, client *aws.Client, summaryChan chan<- *objectRestoreSummary) {
+	storageClass := awssdk.StringValue(object.StorageClass)
+	if !isArchivedStorageClass(object.StorageClass) {
+		summaryChan <- newObjectRestoreSummary(*object.Key, storageClass, "skipped (not archived)", nil)
+		return
+	}
+	_, err := client.S3.RestoreObject(&s3.RestoreObjectInput{
+		Bucket: &bucketName,
+		Key:    object.Key,
+		RestoreRequest: &s3.RestoreRequest{
+			Days:                 &days,
+			GlacierJobParameters: &s3.GlacierJobParameters{Tier: &tier},
+		},
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "RestoreAlreadyInProgress" {
+			summaryChan <- newObjectRestoreSummary(*object.Key, storageClass, RESTORE_STATUS_IN_PROGRESS, nil)
+			return
+		}
+		summaryChan <- newObjectRestoreSummary(*object.Key, storageClass, "failed", aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil))
+		return
+	}
+	summaryChan <- newObjectRestoreSummary(*object.Key, storageClass, "requested", nil)
+}
+
+// setRestoreStatus fetch restore status of archived objects with a bounded number of workers
+func setRestoreStatus(bucketName string, objects []*bucketObjectOutput, client *aws.Client) {
+	jobs := make(chan *bucketObjectOutput)
+	wg := new(sync.WaitGroup)
+	for i := 0; i < RESTORE_STATUS_WORKERS && i < len(objects); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for object := range jobs {
+				status := fetchObjectRestoreStatus(bucketName, *object.key, client)
+				object.restoreStatus = &status
+			}
+		}()
+	}
+	for _, object := range objects {
+		jobs <- object
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func fetchObjectRestoreStatus(bucketName, key string, client *aws.Client) string {
+	apiOutput, err := client.S3.HeadObject(&s3.HeadObjectInput{Bucket: &bucketName, Key: &key})
+	if err != nil {
+		return NO_VALUE
+	}
+	return restoreStatus(apiOutput.Restore)
+}
+
+// ensureObjectRestored check archived object is available for download, with waitRestore it polls until restore completes or maxWait
+func ensureObjectRestored(bucketName, key string, waitRestore bool, maxWait time.Duration, client *aws.Client) *aws.ErrorInfo {
+	deadline := time.Now().Add(maxWait)
+	for {
+		apiOutput, err := client.S3.HeadObject(&s3.HeadObjectInput{Bucket: &bucketName, Key: &key})
+		if err != nil {
+			return aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
+		}
+		if !isArchivedStorageClass(apiOutput.StorageClass) {
+			return nil
+		}
+		status := restoreStatus(apiOutput.Restore)
+		if isRestored(status) {
+			return nil
+		}
+		if !waitRestore {
+			return aws.NewErrorInfo(ObjectArchived(key, *apiOutput.StorageClass), viewer.ERROR, nil)
+		}
+		if status == RESTORE_STATUS_ARCHIVED {
+			return aws.NewErrorInfo(ObjectRestoreNotRequested(key), viewer.ERROR, nil)
+		}
+		if time.Now().Add(RESTORE_POLL_INTERVAL).After(deadline) {
+			return aws.NewErrorInfo(RestoreWaitTimeout(key, maxWait), viewer.ERROR, nil)
+		}
+		fmt.Printf("Restore of %s is in progress, next check in %s\n", key, RESTORE_POLL_INTERVAL)
+		time.Sleep(RESTORE_POLL_INTERVAL)
+	}
+}
+
 func getBucketPolicy(bucket *string, client *aws.Client, bucketinfo *bucketDefinition) *s3.GetBucketPolicyOutput {
 	res, err := client.S3.GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: bucket})
 	if err != nil {
-		errr, _ := err.(awserr.Error)
-		fmt.Println("errCode", errr.Code(), " message ", errr.Message())
 		bucketinfo.SetPolicyAPIError(err)
 		return nil
 	}
@@ -265,11 +691,18 @@ func getBucketencryptionConfig(bucket *string, client *aws.Client, bucketinfo *b
 	return res
 }
 
+func getBucketCorsConfig(bucket *string, client *aws.Client, bucketinfo *bucketDefinition) *s3.GetBucketCorsOutput {
+	res, err := client.S3.GetBucketCors(&s3.GetBucketCorsInput{Bucket: bucket})
+	if err != nil {
+		bucketinfo.SetCorsAPIError(err)
+		return nil
+	}
+	return res
+}
+
 func getBucketLifecycleConfig(bucket *string, client *aws.Client, bucketinfo *bucketDefinition) *s3.GetBucketLifecycleConfigurationOutput {
 	res, err := client.S3.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{Bucket: bucket})
-
 	if err != nil {
-		fmt.Println("Error message", err.Error())
 		bucketinfo.SetLifeCycleError(err)
 		return nil
 	}
@@ -1,20 +1,66 @@
 package s3
 
 import (
+	"cloudctl/expr"
+	ctltime "cloudctl/time"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
+const (
+	SORT_BY_KEY      = "key"
+	SORT_BY_SIZE     = "size"
+	SORT_BY_MODIFIED = "modified"
+)
+
+var (
+	sizeRegex = regexp.MustCompile(`^(\d+)\s*([KMGT]?)(I?B)?$`)
+	sizeUnits = map[string]int64{
+		"":  1,
+		"K": 1 << 10,
+		"M": 1 << 20,
+		"G": 1 << 30,
+		"T": 1 << 40,
+	}
+	// fields of --where expression
+	BucketSchema = expr.Schema{
+		"name":         expr.STRING,
+		"creationDate": expr.TIME,
+	}
+	ObjectSchema = expr.Schema{
+		"key":          expr.STRING,
+		"size":         expr.NUMBER,
+		"lastModified": expr.TIME,
+		"storageClass": expr.STRING,
+		"etag":         expr.STRING,
+	}
+)
+
 type BucketListFilterOptFunc func(*BucketListFilter)
 
+type BucketObjectListFilterOptFunc func(*BucketObjectListFilter)
+
 type BucketListFilter struct {
 	creationDateString *string
 	bucketNameString   *string
+	creationRange      *ctltime.Range
+	where              *expr.Expression
 }
 
 func (f *BucketListFilter) applyCustomFilter(bucket *s3.Bucket) bool {
+	if f.where != nil && !f.where.Match(expr.Record{"name": bucket.Name, "creationDate": bucket.CreationDate}) {
+		return false
+	}
+	if f.creationRange != nil && !f.creationRange.Contains(*bucket.CreationDate) {
+		return false
+	}
 	if f.bucketNameString == nil && f.creationDateString == nil {
 		return true
 	}
@@ -30,6 +76,64 @@ func (f *BucketListFilter) applyCustomFilter(bucket *s3.Bucket) bool {
 	return false
 }
 
+type BucketObjectListFilter struct {
+	minSize        *int64
+	maxSize        *int64
+	modifiedRange  *ctltime.Range
+	storageClasses []string
+	keyRegex       *regexp.Regexp
+	sortBy         string
+	ascending      bool
+	where          *expr.Expression
+}
+
+func (f *BucketObjectListFilter) applyCustomFilter(object *s3.Object) bool {
+	if f.minSize != nil && *object.Size < *f.minSize {
+		return false
+	}
+	if f.maxSize != nil && *object.Size > *f.maxSize {
+		return false
+	}
+	if f.modifiedRange != nil && !f.modifiedRange.Contains(*object.LastModified) {
+		return false
+	}
+	if len(f.storageClasses) != 0 && !storageClassFilter(object.StorageClass, f.storageClasses) {
+		return false
+	}
+	if f.keyRegex != nil && !f.keyRegex.MatchString(*object.Key) {
+		return false
+	}
+	return f.where == nil || f.where.Match(objectRecord(object))
+}
+
+func objectRecord(object *s3.Object) expr.Record {
+	return expr.Record{
+		"key":          object.Key,
+		"size":         object.Size,
+		"lastModified": object.LastModified,
+		"storageClass": object.StorageClass,
+		"etag":         object.ETag,
+	}
+}
+
+func (f *BucketObjectListFilter) sort(objects []*bucketObjectOutput) {
+	less := func(i, j int) bool {
+		return objects[i].lastModified.Before(*objects[j].lastModified)
+	}
+	switch f.sortBy {
+	case SORT_BY_KEY:
+		less = func(i, j int) bool { return *objects[i].key < *objects[j].key }
+	case SORT_BY_SIZE:
+		less = func(i, j int) bool { return *objects[i].sizeInBytes < *objects[j].sizeInBytes }
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		if f.ascending {
+			return less(i, j)
+		}
+		return less(j, i)
+	})
+}
+
 func NewBucketListFilter(optFuncs ...BucketListFilterOptFunc) *BucketListFilter {
 	filter := &BucketListFilter{
 		creationDateString: nil,
@@ -57,6 +161,97 @@ func WithBucketNameFilter(bucketNameString *string) BucketListFilterOptFunc {
 	}
 }
 
+func WithBucketCreationRange(creationRange *ctltime.Range) BucketListFilterOptFunc {
+	return func(blf *BucketListFilter) {
+		blf.creationRange = creationRange
+	}
+}
+
+func WithBucketWhere(where *expr.Expression) BucketListFilterOptFunc {
+	return func(blf *BucketListFilter) {
+		blf.where = where
+	}
+}
+
+// NewBucketObjectListFilter default sort is LastModified DESC
+func NewBucketObjectListFilter(optFuncs ...BucketObjectListFilterOptFunc) *BucketObjectListFilter {
+	filter := &BucketObjectListFilter{
+		sortBy:    SORT_BY_MODIFIED,
+		ascending: false,
+	}
+	for _, optFunc := range optFuncs {
+		optFunc(filter)
+	}
+	return filter
+}
+
+func WithObjectSizeRange(minSize, maxSize *int64) BucketObjectListFilterOptFunc {
+	return func(f *BucketObjectListFilter) {
+		f.minSize = minSize
+		f.maxSize = maxSize
+	}
+}
+
+func WithObjectModifiedRange(modifiedRange *ctltime.Range) BucketObjectListFilterOptFunc {
+	return func(f *BucketObjectListFilter) {
+		f.modifiedRange = modifiedRange
+	}
+}
+
+func WithObjectStorageClasses(storageClasses []string) BucketObjectListFilterOptFunc {
+	return func(f *BucketObjectListFilter) {
+		f.storageClasses = storageClasses
+	}
+}
+
+func WithObjectKeyRegex(keyRegex *regexp.Regexp) BucketObjectListFilterOptFunc {
+	return func(f *BucketObjectListFilter) {
+		f.keyRegex = keyRegex
+	}
+}
+
+func WithObjectSort(sortBy string, ascending bool) BucketObjectListFilterOptFunc {
+	return func(f *BucketObjectListFilter) {
+		f.sortBy = sortBy
+		f.ascending = ascending
+	}
+}
+
+func WithObjectWhere(where *expr.Expression) BucketObjectListFilterOptFunc {
+	return func(f *BucketObjectListFilter) {
+		f.where = where
+	}
+}
+
+// ParseSize parse size in bytes with optional binary unit suffix e.g. 512, 10KB, 2GiB
+func ParseSize(value string) (int64, error) {
+	match := sizeRegex.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, fmt.Errorf("invalid size %q, expected value like 512, 10KB, 5MB or 2GB", value)
+	}
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q, %w", value, err)
+	}
+	unit := sizeUnits[match[2]]
+	if n > math.MaxInt64/unit {
+		return 0, fmt.Errorf("invalid size %q, value out of range", value)
+	}
+	return n * unit, nil
+}
+
+func storageClassFilter(storageClassFromAPI *string, storageClassesFromCLI []string) bool {
+	if storageClassFromAPI == nil {
+		return false
+	}
+	for _, storageClass := range storageClassesFromCLI {
+		if strings.EqualFold(*storageClassFromAPI, storageClass) {
+			return true
+		}
+	}
+	return false
+}
+
 func bucketNameFilter(bucketNameFromAPI, bucketNameFromCLI string) bool {
 	return strings.Contains(bucketNameFromAPI, bucketNameFromCLI)
 }
@@ -3,12 +3,29 @@ package s3
 import (
 	"cloudctl/provider/aws"
 	ctltime "cloudctl/time"
+	"cloudctl/viewer"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
+const (
+	NO_VALUE string = "-"
+
+	RESTORE_STATUS_ARCHIVED    = "archived"
+	RESTORE_STATUS_IN_PROGRESS = "in-progress"
+	RESTORE_STATUS_RESTORED    = "restored"
+)
+
+var (
+	// x-amz-restore header e.g. ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"
+	restoreHeaderRegex = regexp.MustCompile(`ongoing-request="(true|false)"(?:,\s*expiry-date="([^"]+)")?`)
+)
+
 type bucketOjectsDownloadSummary struct {
 	bucketName             string
 	objectsDownloadSummary []*objectDownloadSummary
@@ -25,13 +42,30 @@ type objectDownloadSummary struct {
 
 type bucketOutput struct {
 	name         *string
+	region       *string
 	creationDate *time.Time
 }
 type bucketObjectOutput struct {
-	key          *string
-	sizeInBytes  *int64
-	storageClass *string
-	lastModified *time.Time
+	key           *string
+	sizeInBytes   *int64
+	storageClass  *string
+	lastModified  *time.Time
+	restoreStatus *string
+}
+
+type objectRestoreSummary struct {
+	key          string
+	storageClass string
+	status       string
+	err          *aws.ErrorInfo
+}
+
+type bucketObjectsRestoreSummary struct {
+	bucketName     string
+	days           int64
+	tier           string
+	objectsSummary []*objectRestoreSummary
+	err            *aws.ErrorInfo
 }
 type bucketListOutput struct {
 	buckets []*bucketOutput
@@ -56,22 +90,111 @@ type bucketDefinition struct {
 	encryptionConfigAPIError error
 	lifecycle                *interface{}
 	lifeCycleAPIError        error
+	cors                     *interface{}
+	corsAPIError             error
+}
+
+type bucketOperationStep struct {
+	name string
+	err  *aws.ErrorInfo
+}
+
+type bucketOperationSummary struct {
+	bucketName string
+	operation  string
+	steps      []*bucketOperationStep
+	err        *aws.ErrorInfo
+}
+
+type bucketContentSummary struct {
+	objects       int64
+	versions      int64
+	deleteMarkers int64
+	sizeInBytes   int64
+}
+
+// bucketDeletePlan content is nil when bucket is deleted without --force
+type bucketDeletePlan struct {
+	bucketName string
+	content    *bucketContentSummary
+}
+
+type bucketConfigChangeOutput struct {
+	bucketName    string
+	resource      string
+	action        string
+	currentConfig string
+	desiredConfig string
+	desired       interface{}
+	err           *aws.ErrorInfo
 }
 
 func newBucketOutput(bucket *s3.Bucket, tz *ctltime.Timezone) *bucketOutput {
+	novalue := NO_VALUE
 	return &bucketOutput{
 		name:         bucket.Name,
+		region:       &novalue,
 		creationDate: tz.AdaptTimezone(bucket.CreationDate),
 	}
 }
 
 func newBucketObjectOutput(o *s3.Object, tz *ctltime.Timezone) *bucketObjectOutput {
+	novalue := NO_VALUE
 	return &bucketObjectOutput{
-		key:          o.Key,
-		sizeInBytes:  o.Size,
-		storageClass: o.StorageClass,
-		lastModified: tz.AdaptTimezone(o.LastModified),
+		key:           o.Key,
+		sizeInBytes:   o.Size,
+		storageClass:  o.StorageClass,
+		lastModified:  tz.AdaptTimezone(o.LastModified),
+		restoreStatus: &novalue,
+	}
+}
+
+func (o *bucketOperationSummary) addStep(name string, err error) bool {
+	step := &bucketOperationStep{name: name}
+	if err != nil {
+		step.err = aws.NewErrorInfo(aws.AWSError(err), viewer.ERROR, nil)
 	}
+	o.steps = append(o.steps, step)
+	return err == nil
+}
+
+func newObjectRestoreSummary(key, storageClass, status string, err *aws.ErrorInfo) *objectRestoreSummary {
+	return &objectRestoreSummary{
+		key:          key,
+		storageClass: storageClass,
+		status:       status,
+		err:          err,
+	}
+}
+
+// objects of these storage classes need to be restored before download
+func isArchivedStorageClass(storageClass *string) bool {
+	if storageClass == nil {
+		return false
+	}
+	return *storageClass == s3.ObjectStorageClassGlacier || *storageClass == s3.ObjectStorageClassDeepArchive
+}
+
+// restoreStatus convert x-amz-restore header to a readable status
+func restoreStatus(restoreHeader *string) string {
+	if restoreHeader == nil {
+		return RESTORE_STATUS_ARCHIVED
+	}
+	match := restoreHeaderRegex.FindStringSubmatch(*restoreHeader)
+	if match == nil {
+		return *restoreHeader
+	}
+	if match[1] == "true" {
+		return RESTORE_STATUS_IN_PROGRESS
+	}
+	if len(match[2]) != 0 {
+		return fmt.Sprintf("%s (until %s)", RESTORE_STATUS_RESTORED, match[2])
+	}
+	return RESTORE_STATUS_RESTORED
+}
+
+func isRestored(status string) bool {
+	return status != RESTORE_STATUS_ARCHIVED && status != RESTORE_STATUS_IN_PROGRESS
 }
 
 func newBucketObjectDownloadSummary(key, fileName string, numBytesWrite int64, timeElapsed time.Duration, err *aws.ErrorInfo) *objectDownloadSummary {
@@ -84,6 +207,37 @@ func newBucketObjectDownloadSummary(key, fileName string, numBytesWrite int64, t
 	}
 }
 
+type bucketConfiguration struct {
+	resource string
+	config   interface{}
+	err      error
+}
+
+// configurations of bucket in view order, config is the api output of the resource
+func (o *bucketDefinition) configurations() []bucketConfiguration {
+	value := func(data *interface{}) interface{} {
+		if data == nil {
+			return nil
+		}
+		return *data
+	}
+	policy := value(o.policy)
+	if output, ok := policy.(*s3.GetBucketPolicyOutput); ok {
+		var document interface{}
+		if err := json.Unmarshal([]byte(awssdk.StringValue(output.Policy)), &document); err == nil {
+			policy = document
+		}
+	}
+	return []bucketConfiguration{
+		{resource: RESOURCE_POLICY, config: policy, err: o.policyAPIErr},
+		{resource: RESOURCE_VERSIONING, config: value(o.version), err: o.versionAPIErr},
+		{resource: RESOURCE_TAGS, config: value(o.tags), err: o.tagsAPIError},
+		{resource: RESOURCE_ENCRYPTION, config: value(o.encryptionConfig), err: o.encryptionConfigAPIError},
+		{resource: RESOURCE_LIFECYCLE, config: value(o.lifecycle), err: o.lifeCycleAPIError},
+		{resource: RESOURCE_CORS, config: value(o.cors), err: o.corsAPIError},
+	}
+}
+
 func (o *bucketDefinition) SetBucketName(bucketName string) *bucketDefinition {
 	o.bucketName = &bucketName
 	return o
@@ -113,6 +267,11 @@ func (o *bucketDefinition) SetLifeCycle(data interface{}) *bucketDefinition {
 	return o
 }
 
+func (o *bucketDefinition) SetCors(data interface{}) *bucketDefinition {
+	o.cors = &data
+	return o
+}
+
 func (o *bucketDefinition) SetPolicyAPIError(err error) *bucketDefinition {
 	o.policyAPIErr = err
 	return o
@@ -137,32 +296,7 @@ func (o *bucketDefinition) SetLifeCycleError(err error) *bucketDefinition {
 	return o
 }
 
-func (o bucketDefinition) Pretty() {
-	if o.encryptionConfigAPIError != nil {
-		fmt.Println("encryptionConfigAPIError", o.encryptionConfigAPIError)
-	} else {
-		fmt.Println("encryptionConfig", *o.encryptionConfig)
-	}
-	if o.tagsAPIError != nil {
-		fmt.Println("tagsAPIError", o.tagsAPIError)
-	} else {
-		fmt.Println("tags", *o.tags)
-	}
-	if o.policyAPIErr != nil {
-		fmt.Println("policyAPIErr", o.policyAPIErr)
-	} else {
-		fmt.Println("policy", *o.policy)
-	}
-	if o.versionAPIErr != nil {
-		fmt.Println("versionAPIErr", o.versionAPIErr)
-	} else {
-		fmt.Println("versioning", *o.version)
-	}
-	if o.lifeCycleAPIError != nil {
-
-		fmt.Println("lifeCycleAPIError", o.lifeCycleAPIError)
-	} else {
-		fmt.Println("lifecycle", *o.lifecycle)
-	}
-
+func (o *bucketDefinition) SetCorsAPIError(err error) *bucketDefinition {
+	o.corsAPIError = err
+	return o
 }
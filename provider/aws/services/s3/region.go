@@ -0,0 +1,46 @@
+package s3
+
+import (
+	"cloudctl/provider/aws"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// bucket name -> region, bucket region never changes during command execution
+var bucketRegions sync.Map
+
+func bucketRegion(bucketName string, client *aws.Client) (string, error) {
+	if region, ok := bucketRegions.Load(bucketName); ok {
+		return region.(string), nil
+	}
+	region, err := fetchBucketLocation(bucketName, client)
+	if err != nil {
+		// GetBucketLocation is allowed to bucket owner only, fallback to HeadBucket
+		region, err = s3manager.GetBucketRegionWithClient(awssdk.BackgroundContext(), client.S3, bucketName)
+		if err != nil {
+			return "", err
+		}
+	}
+	bucketRegions.Store(bucketName, region)
+	return region, nil
+}
+
+func fetchBucketLocation(bucketName string, client *aws.Client) (string, error) {
+	apiOutput, err := client.S3.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: &bucketName})
+	if err != nil {
+		return "", err
+	}
+	return s3.NormalizeBucketLocation(awssdk.StringValue(apiOutput.LocationConstraint)), nil
+}
+
+// bucketClient return client of the bucket region, fallback to provided client if region can't be detected
+func bucketClient(bucketName string, client *aws.Client) *aws.Client {
+	region, err := bucketRegion(bucketName, client)
+	if err != nil {
+		return client
+	}
+	return client.ForRegion(region)
+}
@@ -1,14 +1,15 @@
 package s3
 
 import (
+	"cloudctl/provider/aws"
 	"cloudctl/viewer"
 	"fmt"
-	"sort"
 )
 
 var (
 	bucketListTableHeader = viewer.Row{
 		"Name",
+		"Region",
 		"CreationDate",
 	}
 	bucketObjectsTableHeader = viewer.Row{
@@ -16,6 +17,30 @@ var (
 		"Size(Bytes)",
 		"StorageClass",
 		"LastModified",
+		"RestoreStatus",
+	}
+	bucketOperationSummaryTableHeader = viewer.Row{
+		"Step",
+		"Status",
+		"Error",
+	}
+	bucketContentSummaryTableHeader = viewer.Row{
+		"Objects",
+		"Versions",
+		"DeleteMarkers",
+		"Size(Bytes)",
+	}
+	bucketConfigChangeTableHeader = viewer.Row{
+		"Bucket",
+		"Resource",
+		"Action",
+		"Status",
+	}
+	bucketObjectsRestoreSummaryTableHeader = viewer.Row{
+		"Key",
+		"StorageClass",
+		"Status",
+		"Error",
 	}
 	bucketObjectsDownloadSummaryTableHeader = viewer.Row{
 		"source",
@@ -40,9 +65,10 @@ func bucketListViewer(o interface{}) viewer.Viewer {
 	tViewer.AddHeader(bucketListTableHeader)
 	tViewer.SetTitle("Buckets")
 	for _, bucket := range data.buckets {
-		tViewer.AddRow(viewer.Row{
-			*bucket.name,
-			bucket.creationDate.String(),
+		tViewer.AddFields(viewer.Fields{
+			"Name":         *bucket.name,
+			"Region":       *bucket.region,
+			"CreationDate": *bucket.creationDate,
 		})
 	}
 	return tViewer
@@ -65,17 +91,13 @@ func bucketObjectsViewer(o interface{}) viewer.Viewer {
 		tViewer.AddHeader(bucketObjectsTableHeader)
 		tViewer.SetTitle(*data.bucketName)
 
-		// sort by LastModified DESC
-		sort.Slice(data.objects, func(i, j int) bool {
-			return data.objects[i].lastModified.After(*data.objects[j].lastModified)
-		})
-
 		for _, content := range data.objects {
-			tViewer.AddRow(viewer.Row{
-				*content.key,
-				*content.sizeInBytes,
-				*content.storageClass,
-				*content.lastModified,
+			tViewer.AddFields(viewer.Fields{
+				"Key":           *content.key,
+				"Size(Bytes)":   *content.sizeInBytes,
+				"StorageClass":  *content.storageClass,
+				"LastModified":  *content.lastModified,
+				"RestoreStatus": *content.restoreStatus,
 			})
 		}
 		compoundViewer.AddViewer(tViewer)
@@ -105,20 +127,20 @@ func bucketObjectsDownloadSummaryViewer(o interface{}) viewer.Viewer {
 	tViewer.SetTitle(fmt.Sprintf("[%s]: Download Summary", data.bucketName))
 	for _, summary := range data.objectsDownloadSummary {
 		if summary.err != nil {
-			tViewer.AddRow(viewer.Row{
-				summary.source,
-				summary.destination,
-				summary.sizeinBytes,
-				summary.timeElapsed,
-				summary.err.Err.Error(),
+			tViewer.AddFields(viewer.Fields{
+				"source":      summary.source,
+				"destination": summary.destination,
+				"size(bytes)": summary.sizeinBytes,
+				"timeElapsed": summary.timeElapsed,
+				"error":       summary.err.Err.Error(),
 			})
 		} else {
-			tViewer.AddRow(viewer.Row{
-				summary.source,
-				summary.destination,
-				summary.sizeinBytes,
-				summary.timeElapsed,
-				"N/A",
+			tViewer.AddFields(viewer.Fields{
+				"source":      summary.source,
+				"destination": summary.destination,
+				"size(bytes)": summary.sizeinBytes,
+				"timeElapsed": summary.timeElapsed,
+				"error":       "N/A",
 			})
 		}
 
@@ -127,7 +149,120 @@ func bucketObjectsDownloadSummaryViewer(o interface{}) viewer.Viewer {
 
 }
 
+func bucketObjectsRestoreSummaryViewer(o interface{}) viewer.Viewer {
+	data := o.(*bucketObjectsRestoreSummary)
+	if data.err != nil {
+		errViewer := viewer.NewErrorViewer()
+		errViewer.SetErrorMessage(data.err.Err.Error())
+		errViewer.SetErrorType(data.err.ErrorType)
+		return errViewer
+	}
+
+	tViewer := viewer.NewTableViewer()
+	tViewer.AddHeader(bucketObjectsRestoreSummaryTableHeader)
+	tViewer.SetTitle(fmt.Sprintf("[%s]: Restore Summary (tier: %s, days: %d)", data.bucketName, data.tier, data.days))
+	for _, summary := range data.objectsSummary {
+		errorMessage := "N/A"
+		if summary.err != nil {
+			errorMessage = summary.err.Err.Error()
+		}
+		tViewer.AddFields(viewer.Fields{
+			"Key":          summary.key,
+			"StorageClass": summary.storageClass,
+			"Status":       summary.status,
+			"Error":        errorMessage,
+		})
+	}
+	return tViewer
+}
+
+func bucketConfigChangeViewer(o interface{}) viewer.Viewer {
+	data := o.(*bucketConfigChangeOutput)
+	if data.err != nil {
+		errViewer := viewer.NewErrorViewer()
+		errViewer.SetErrorMessage(data.err.Err.Error())
+		errViewer.SetErrorType(data.err.ErrorType)
+		return errViewer
+	}
+
+	tViewer := viewer.NewTableViewer()
+	tViewer.AddHeader(bucketConfigChangeTableHeader)
+	tViewer.SetTitle("Change Summary")
+	tViewer.AddFields(viewer.Fields{
+		"Bucket":   data.bucketName,
+		"Resource": data.resource,
+		"Action":   data.action,
+		"Status":   "applied",
+	})
+	return tViewer
+}
+
+func bucketConfigChangePlanViewer(o interface{}) viewer.Viewer {
+	data := o.(*bucketConfigChangeOutput)
+	diffViewer := viewer.NewDiffViewer()
+	diffViewer.SetTitle(fmt.Sprintf("[%s]: %s %s", data.bucketName, data.resource, data.action))
+	diffViewer.SetContent(data.currentConfig, data.desiredConfig)
+	return diffViewer
+}
+
+func bucketDeletePlanViewer(o interface{}) viewer.Viewer {
+	data := o.(*bucketDeletePlan)
+	cViewer := viewer.NewCompoundViewer()
+	if data.content == nil {
+		return cViewer
+	}
+	tViewer := viewer.NewTableViewer()
+	tViewer.SetTitle(fmt.Sprintf("[%s]: Content to be deleted", data.bucketName))
+	tViewer.AddHeader(bucketContentSummaryTableHeader)
+	tViewer.AddFields(viewer.Fields{
+		"Objects":       data.content.objects,
+		"Versions":      data.content.versions,
+		"DeleteMarkers": data.content.deleteMarkers,
+		"Size(Bytes)":   data.content.sizeInBytes,
+	})
+	return cViewer.AddViewer(tViewer)
+}
+
+func bucketOperationSummaryViewer(o interface{}) viewer.Viewer {
+	data := o.(*bucketOperationSummary)
+	if data.err != nil {
+		errViewer := viewer.NewErrorViewer()
+		errViewer.SetErrorMessage(data.err.Err.Error())
+		errViewer.SetErrorType(data.err.ErrorType)
+		return errViewer
+	}
+
+	tViewer := viewer.NewTableViewer()
+	tViewer.AddHeader(bucketOperationSummaryTableHeader)
+	tViewer.SetTitle(fmt.Sprintf("[%s]: %s Summary", data.bucketName, data.operation))
+	for _, step := range data.steps {
+		if step.err != nil {
+			tViewer.AddFields(viewer.Fields{"Step": step.name, "Status": "failed", "Error": step.err.Err.Error()})
+		} else {
+			tViewer.AddFields(viewer.Fields{"Step": step.name, "Status": "done", "Error": "N/A"})
+		}
+	}
+	return tViewer
+}
+
 func bucketConfigurationViewer(o interface{}) viewer.Viewer {
-	o.(*bucketDefinition).Pretty()
-	return nil
+	data := o.(*bucketDefinition)
+	cViewer := viewer.NewCompoundViewer()
+	for _, configuration := range data.configurations() {
+		content := NO_VALUE
+		if configuration.err != nil {
+			if ignoreNoSuchConfiguration(configuration.err) != nil {
+				content = aws.AWSError(configuration.err).Error()
+			}
+		} else if rendered, err := renderConfig(configuration.config); err != nil {
+			content = err.Error()
+		} else if len(rendered) != 0 {
+			content = rendered
+		}
+		tViewer := viewer.NewTextViewer()
+		tViewer.SetTitle(fmt.Sprintf("[%s]: %s", *data.bucketName, configuration.resource))
+		tViewer.SetContent(content)
+		cViewer.AddViewer(tViewer)
+	}
+	return cViewer
 }
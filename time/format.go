@@ -0,0 +1,77 @@
+package time
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TIME_FORMAT_ISO      = "iso"
+	TIME_FORMAT_RFC1123  = "rfc1123"
+	TIME_FORMAT_RELATIVE = "relative"
+)
+
+var (
+	formatLayouts = map[string]string{
+		TIME_FORMAT_ISO:     time.RFC3339,
+		TIME_FORMAT_RFC1123: time.RFC1123,
+	}
+	// layout of absolute time, empty means time.Time String()
+	displayLayout = ""
+	// show relative time e.g. 3d ago, alone or next to absolute time
+	displayRelative = false
+)
+
+// SetFormat set how Format renders times, iso | rfc1123 | relative, absolute format can be combined with relative as iso+relative
+func SetFormat(format string) error {
+	displayLayout, displayRelative = "", false
+	for _, part := range strings.Split(format, "+") {
+		switch part = strings.ToLower(strings.TrimSpace(part)); {
+		case len(part) == 0:
+		case part == TIME_FORMAT_RELATIVE:
+			displayRelative = true
+		case len(formatLayouts[part]) != 0 && len(displayLayout) == 0:
+			displayLayout = formatLayouts[part]
+		default:
+			return fmt.Errorf("invalid time format %q, expected iso, rfc1123, relative or iso+relative", format)
+		}
+	}
+	return nil
+}
+
+// Format render time as configured by SetFormat
+func Format(t time.Time) string {
+	absolute := t.Round(0).String()
+	if len(displayLayout) != 0 {
+		absolute = t.Format(displayLayout)
+	}
+	if !displayRelative {
+		return absolute
+	}
+	relative := FormatRelative(t, time.Now())
+	if len(displayLayout) == 0 {
+		return relative
+	}
+	return fmt.Sprintf("%s (%s)", absolute, relative)
+}
+
+// FormatRelative render t relative to now e.g. 3d ago, in 2h
+func FormatRelative(t, now time.Time) string {
+	if t.After(now) {
+		return "in " + FormatAge(t.Sub(now))
+	}
+	return FormatAge(now.Sub(t)) + " ago"
+}
+
+// FormatAge render age in the largest fitting unit e.g. 3d, 5h, 10m
+func FormatAge(age time.Duration) string {
+	switch {
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int64(age/(24*time.Hour)))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int64(age/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int64(age/time.Minute))
+	}
+}
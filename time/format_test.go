@@ -0,0 +1,91 @@
+package time
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetFormat(t *testing.T) {
+	t.Cleanup(func() { SetFormat("") })
+	tests := []struct {
+		format   string
+		layout   string
+		relative bool
+		valid    bool
+	}{
+		{"", "", false, true},
+		{"iso", time.RFC3339, false, true},
+		{"RFC1123", time.RFC1123, false, true},
+		{"relative", "", true, true},
+		{"iso+relative", time.RFC3339, true, true},
+		{"relative + rfc1123", time.RFC1123, true, true},
+		{"xml", "", false, false},
+		{"iso+rfc1123", "", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			err := SetFormat(test.format)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("expected error for %q", test.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if displayLayout != test.layout || displayRelative != test.relative {
+				t.Errorf("layout = %q relative = %v, want %q %v", displayLayout, displayRelative, test.layout, test.relative)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	t.Cleanup(func() { SetFormat("") })
+	fixed := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	past := time.Now().Add(-(3*24*time.Hour + time.Hour))
+	future := time.Now().Add(2*time.Hour + time.Minute)
+	tests := []struct {
+		format string
+		t      time.Time
+		want   string
+	}{
+		{"", fixed, "2024-01-31 10:00:00 +0000 UTC"},
+		{"iso", fixed, "2024-01-31T10:00:00Z"},
+		{"rfc1123", fixed, "Wed, 31 Jan 2024 10:00:00 UTC"},
+		{"relative", past, "3d ago"},
+		{"relative", future, "in 2h"},
+		{"iso+relative", past.Truncate(time.Second), past.Truncate(time.Second).Format(time.RFC3339) + " (3d ago)"},
+	}
+	for _, test := range tests {
+		t.Run(test.format+" "+test.want, func(t *testing.T) {
+			if err := SetFormat(test.format); err != nil {
+				t.Fatal(err)
+			}
+			if got := Format(test.t); got != test.want {
+				t.Errorf("Format() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{now.Add(-30 * time.Second), "0m ago"},
+		{now.Add(-45 * time.Minute), "45m ago"},
+		{now.Add(-5*time.Hour - 59*time.Minute), "5h ago"},
+		{now.Add(-24 * time.Hour), "1d ago"},
+		{now.Add(-400 * 24 * time.Hour), "400d ago"},
+		{now.Add(90 * time.Minute), "in 1h"},
+	}
+	for _, test := range tests {
+		if got := FormatRelative(test.t, now); got != test.want {
+			t.Errorf("FormatRelative(%s) = %q, want %q", test.t, got, test.want)
+		}
+	}
+}
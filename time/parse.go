@@ -0,0 +1,68 @@
+package time
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	dayWeekDurationRegex = regexp.MustCompile(`^(\d+)([dw])$`)
+	absoluteTimeLayouts  = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+	}
+)
+
+// ParseDuration extends time.ParseDuration with day(d) and week(w) units e.g. 7d, 2w, negative durations are rejected
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if match := dayWeekDurationRegex.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		unit := 24 * time.Hour
+		if match[2] == "w" {
+			unit = 7 * 24 * time.Hour
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, expected value like 30m, 2h, 7d or 2w", value)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid duration %q, must not be negative", value)
+	}
+	return d, nil
+}
+
+// ParseTime parse an absolute time (RFC3339 or YYYY-MM-DD), keyword (now, today, yesterday) or a duration relative to now
+// e.g. 7d means 7 days ago, time without offset and keywords are interpreted in location of now
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "now":
+		return now, nil
+	case "today":
+		return startOfDay(now), nil
+	case "yesterday":
+		return startOfDay(now).AddDate(0, 0, -1), nil
+	}
+	for _, layout := range absoluteTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	d, err := ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339, YYYY-MM-DD, today, yesterday or duration like 2h, 7d", value)
+	}
+	return now.Add(-d), nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
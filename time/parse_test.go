@@ -0,0 +1,83 @@
+package time
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		valid bool
+	}{
+		{"30m", 30 * time.Minute, true},
+		{"2h", 2 * time.Hour, true},
+		{" 7d ", 7 * 24 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"0d", 0, true},
+		{"-7d", 0, false},
+		{"-2h", 0, false},
+		{"7days", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			d, err := ParseDuration(test.value)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("expected error, got %s", d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d != test.want {
+				t.Errorf("ParseDuration() = %s, want %s", d, test.want)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("tz database isn't available")
+	}
+	now := time.Date(2024, 1, 31, 10, 30, 0, 0, paris)
+	tests := []struct {
+		value string
+		want  time.Time
+		valid bool
+	}{
+		{"now", now, true},
+		{"today", time.Date(2024, 1, 31, 0, 0, 0, 0, paris), true},
+		{"Yesterday", time.Date(2024, 1, 30, 0, 0, 0, 0, paris), true},
+		{"2h", now.Add(-2 * time.Hour), true},
+		{"7d", now.AddDate(0, 0, -7), true},
+		{"2024-01-15", time.Date(2024, 1, 15, 0, 0, 0, 0, paris), true},
+		{"2024-01-15T08:00:00", time.Date(2024, 1, 15, 8, 0, 0, 0, paris), true},
+		{"2024-01-15 08:00:00", time.Date(2024, 1, 15, 8, 0, 0, 0, paris), true},
+		{"2024-01-15T08:00:00Z", time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC), true},
+		{"-7d", time.Time{}, false},
+		{"15/01/2024", time.Time{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseTime(test.value, now)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("ParseTime() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package time
+
+import (
+	"fmt"
+	"time"
+)
+
+// Range is a time window, zero since or until means unbounded on that side
+type Range struct {
+	Since time.Time
+	Until time.Time
+}
+
+// ParseRange parse since & until with ParseTime, empty value is unbounded, nil is returned if both are empty
+func ParseRange(since, until string, now time.Time) (*Range, error) {
+	if len(since) == 0 && len(until) == 0 {
+		return nil, nil
+	}
+	r := &Range{}
+	var err error
+	if len(since) != 0 {
+		if r.Since, err = ParseTime(since, now); err != nil {
+			return nil, err
+		}
+	}
+	if len(until) != 0 {
+		if r.Until, err = ParseTime(until, now); err != nil {
+			return nil, err
+		}
+	}
+	if !r.Since.IsZero() && !r.Until.IsZero() && !r.Since.Before(r.Until) {
+		return nil, fmt.Errorf("invalid time range, %s is not before %s", r.Since.Format(time.RFC3339), r.Until.Format(time.RFC3339))
+	}
+	return r, nil
+}
+
+// Contains since is inclusive & until is exclusive, so --since yesterday --until today is whole yesterday
+func (r *Range) Contains(t time.Time) bool {
+	if !r.Since.IsZero() && t.Before(r.Since) {
+		return false
+	}
+	return r.Until.IsZero() || t.Before(r.Until)
+}
@@ -0,0 +1,68 @@
+package time
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	now := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		since string
+		until string
+		want  *Range
+		valid bool
+	}{
+		{"unbounded", "", "", nil, true},
+		{"since only", "2024-01-01", "", &Range{Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, true},
+		{"until only", "", "today", &Range{Until: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}, true},
+		{"whole yesterday", "yesterday", "today", &Range{Since: time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}, true},
+		{"since after until", "today", "yesterday", nil, false},
+		{"empty window", "today", "today", nil, false},
+		{"invalid since", "-1d", "", nil, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseRange(test.since, test.until, now)
+			if !test.valid {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (got == nil) != (test.want == nil) || got != nil && (!got.Since.Equal(test.want.Since) || !got.Until.Equal(test.want.Until)) {
+				t.Errorf("ParseRange() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	since := time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		r    Range
+		t    time.Time
+		want bool
+	}{
+		{"since is inclusive", Range{Since: since, Until: until}, since, true},
+		{"before since", Range{Since: since, Until: until}, since.Add(-time.Nanosecond), false},
+		{"within", Range{Since: since, Until: until}, since.Add(12 * time.Hour), true},
+		{"until is exclusive", Range{Since: since, Until: until}, until, false},
+		{"just before until", Range{Since: since, Until: until}, until.Add(-time.Nanosecond), true},
+		{"unbounded since", Range{Until: until}, time.Time{}.Add(time.Hour), true},
+		{"unbounded until", Range{Since: since}, until.AddDate(10, 0, 0), true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.r.Contains(test.t); got != test.want {
+				t.Errorf("Contains(%s) = %v, want %v", test.t, got, test.want)
+			}
+		})
+	}
+}
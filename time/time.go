@@ -1,33 +1,87 @@
 package time
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
+	// embed IANA database, so zones resolve on systems without zoneinfo
+	_ "time/tzdata"
+)
+
+const (
+	DEFAULT_TIMEZONE = "utc"
+	LOCAL_TIMEZONE   = "local"
 )
 
 type Timezone struct {
 	identifier string
+	location   *time.Location
 }
 
 var (
-	supportedTimezones = map[string]*Timezone{
-		"utc": {
-			identifier: "UTC",
-		},
-		"los_angeles": {
-			identifier: "America/Los_Angeles",
-		},
-		"tokyo": {
-			identifier: "Asia/Tokyo",
-		},
+	// short names kept for backward compatibility, any IANA name is accepted as well
+	supportedTimezones = map[string]string{
+		"utc":         "UTC",
+		"los_angeles": "America/Los_Angeles",
+		"tokyo":       "Asia/Tokyo",
 	}
 )
 
-func GetTZ(tz_short_identifier string) *Timezone {
-	return supportedTimezones[tz_short_identifier]
+// LoadTZ resolve short name, local or IANA name e.g. Europe/Paris, short names & local are case insensitive
+func LoadTZ(name string) (*Timezone, error) {
+	if len(name) == 0 {
+		name = DEFAULT_TIMEZONE
+	}
+	if strings.EqualFold(name, LOCAL_TIMEZONE) {
+		return &Timezone{identifier: time.Local.String(), location: time.Local}, nil
+	}
+	identifier := name
+	if v, ok := supportedTimezones[strings.ToLower(name)]; ok {
+		identifier = v
+	}
+	loc, err := time.LoadLocation(identifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q, expected IANA name like Europe/Paris, %s or one of [%s]", name, LOCAL_TIMEZONE, shortNames())
+	}
+	return &Timezone{identifier: identifier, location: loc}, nil
+}
+
+// GetTZ fallback to UTC for invalid name, name is validated with LoadTZ when flags are parsed
+func GetTZ(name string) *Timezone {
+	tz, err := LoadTZ(name)
+	if err != nil {
+		return &Timezone{identifier: "UTC", location: time.UTC}
+	}
+	return tz
+}
+
+func shortNames() string {
+	names := []string{}
+	for name := range supportedTimezones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// Location of timezone, UTC for nil timezone
+func (tz *Timezone) Location() *time.Location {
+	if tz == nil || tz.location == nil {
+		return time.UTC
+	}
+	return tz.location
+}
+
+// Now is current time in timezone, relative times & dates without offset are resolved against it
+func (tz *Timezone) Now() time.Time {
+	return time.Now().In(tz.Location())
 }
 
 func (tz *Timezone) AdaptTimezone(t *time.Time) *time.Time {
-	loc, _ := time.LoadLocation(tz.identifier)
-	adaptTzTime := t.In(loc)
+	if t == nil {
+		return nil
+	}
+	adaptTzTime := t.In(tz.Location())
 	return &adaptTzTime
 }
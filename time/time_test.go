@@ -0,0 +1,67 @@
+package time
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadTZ(t *testing.T) {
+	tests := []struct {
+		name       string
+		identifier string
+		// empty when name is valid, otherwise part of error message
+		err string
+	}{
+		{"", "UTC", ""},
+		{"utc", "UTC", ""},
+		{"Tokyo", "Asia/Tokyo", ""},
+		{"los_angeles", "America/Los_Angeles", ""},
+		{"Europe/Paris", "Europe/Paris", ""},
+		{"LOCAL", time.Local.String(), ""},
+		{"Mars/Olympus", "", `invalid timezone "Mars/Olympus"`},
+		{"paris", "", "los_angeles,tokyo,utc"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tz, err := LoadTZ(test.name)
+			if len(test.err) != 0 {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, want error containing %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tz.identifier != test.identifier {
+				t.Errorf("identifier = %s, want %s", tz.identifier, test.identifier)
+			}
+		})
+	}
+}
+
+func TestGetTZ(t *testing.T) {
+	if location := GetTZ("Mars/Olympus").Location(); location != time.UTC {
+		t.Errorf("GetTZ of invalid name = %s, want UTC", location)
+	}
+	if location := GetTZ("Asia/Tokyo").Location(); location.String() != "Asia/Tokyo" {
+		t.Errorf("GetTZ(Asia/Tokyo) = %s", location)
+	}
+	var tz *Timezone
+	if location := tz.Location(); location != time.UTC {
+		t.Errorf("location of nil timezone = %s, want UTC", location)
+	}
+}
+
+func TestAdaptTimezone(t *testing.T) {
+	tz := GetTZ("tokyo")
+	if tz.AdaptTimezone(nil) != nil {
+		t.Errorf("AdaptTimezone(nil) must be nil")
+	}
+	utc := time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC)
+	adapted := tz.AdaptTimezone(&utc)
+	if !adapted.Equal(utc) || adapted.Hour() != 5 || adapted.Location().String() != "Asia/Tokyo" {
+		t.Errorf("AdaptTimezone(%s) = %s", utc, adapted)
+	}
+}
@@ -0,0 +1,164 @@
+package viewer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// value of custom column whose field isn't in the table
+	MISSING_FIELD_VALUE = "<none>"
+	// value of empty Map cell
+	EMPTY_MAP_VALUE = "-"
+)
+
+// CustomColumn is a NAME:.field.key column of --custom-columns, field is a column name of the table
+// and path select keys of nested Map value e.g. .tags.env
+type CustomColumn struct {
+	Name  string
+	Field string
+	Path  []string
+}
+
+// Map is a cell value rendered as sorted key=value lines, its keys are selected by nested custom column path
+type Map map[string]string
+
+// columnOptions applies to every table, set once from global flags
+type columnOptions struct {
+	columns       []string
+	customColumns []CustomColumn
+	wide          bool
+}
+
+// columnUsage tracks selected columns matched by rendered tables, so unknown column names are reported
+type columnUsage struct {
+	rendered  bool
+	matched   map[string]bool
+	available []string
+	seen      map[string]bool
+}
+
+var (
+	tableColumnOptions = columnOptions{}
+	tableColumnUsage   = newColumnUsage()
+)
+
+func newColumnUsage() *columnUsage {
+	return &columnUsage{matched: map[string]bool{}, seen: map[string]bool{}}
+}
+
+func (u *columnUsage) addAvailable(name string) {
+	if !u.seen[fieldName(name)] {
+		u.seen[fieldName(name)] = true
+		u.available = append(u.available, name)
+	}
+}
+
+// SetColumnOptions select columns of every table, either by column names or custom columns, wide shows columns hidden by default
+func SetColumnOptions(columns []string, customColumns []CustomColumn, wide bool) {
+	tableColumnOptions = columnOptions{columns: columns, customColumns: customColumns, wide: wide}
+	tableColumnUsage = newColumnUsage()
+}
+
+// ColumnSelectionError return error listing --columns & --custom-columns fields which aren't a column of any rendered table
+func ColumnSelectionError() error {
+	if !tableColumnUsage.rendered {
+		return nil
+	}
+	unknown := []string{}
+	for _, name := range tableColumnOptions.columns {
+		if len(fieldName(name)) != 0 && !tableColumnUsage.matched[fieldName(name)] {
+			unknown = append(unknown, name)
+		}
+	}
+	for _, custom := range tableColumnOptions.customColumns {
+		if !tableColumnUsage.matched[fieldName(custom.Field)] {
+			unknown = append(unknown, "."+strings.Join(append([]string{custom.Field}, custom.Path...), "."))
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown column(s) %s, available columns are %s", strings.Join(unknown, ", "), strings.Join(tableColumnUsage.available, ", "))
+}
+
+// ParseCustomColumns parse kubectl like NAME:.field,NAME:.field spec e.g. ID:.id,IP:.privateIp,ENV:.tags.env
+func ParseCustomColumns(spec string) ([]CustomColumn, error) {
+	columns := []CustomColumn{}
+	if len(strings.TrimSpace(spec)) == 0 {
+		return columns, nil
+	}
+	for _, column := range strings.Split(spec, ",") {
+		parts := strings.SplitN(strings.TrimSpace(column), ":", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 || !strings.HasPrefix(parts[1], ".") {
+			return nil, fmt.Errorf("invalid custom column %q, expected NAME:.field e.g. IP:.privateIp", column)
+		}
+		path := strings.Split(strings.TrimPrefix(parts[1], "."), ".")
+		for _, key := range path {
+			if len(key) == 0 {
+				return nil, fmt.Errorf("invalid custom column %q, expected NAME:.field e.g. IP:.privateIp or ENV:.tags.env", column)
+			}
+		}
+		columns = append(columns, CustomColumn{Name: parts[0], Field: path[0], Path: path[1:]})
+	}
+	return columns, nil
+}
+
+// fieldName normalize column header into field name, so Size(GiB), size-gib and sizegib are the same field
+func fieldName(header interface{}) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, fmt.Sprint(header))
+}
+
+// shortFieldName is field name without unit suffix, e.g. Size(GiB) => size
+func shortFieldName(header interface{}) string {
+	name := fmt.Sprint(header)
+	if i := strings.Index(name, "("); i > 0 {
+		name = name[:i]
+	}
+	return fieldName(name)
+}
+
+// lookupPath select value of nested keys, keys are case sensitive like tag keys
+func lookupPath(value interface{}, path []string) (interface{}, bool) {
+	for _, key := range path {
+		var ok bool
+		switch m := value.(type) {
+		case Map:
+			value, ok = m[key]
+		case map[string]string:
+			value, ok = m[key]
+		case map[string]interface{}:
+			value, ok = m[key]
+		default:
+			return nil, false
+		}
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// String render map as sorted key=value lines
+func (m Map) String() string {
+	if len(m) == 0 {
+		return EMPTY_MAP_VALUE
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := []string{}
+	for _, k := range keys {
+		lines = append(lines, fmt.Sprintf("%s=%s", k, m[k]))
+	}
+	return strings.Join(lines, "\n")
+}
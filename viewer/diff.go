@@ -0,0 +1,111 @@
+package viewer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+type DiffViewer struct {
+	title string
+	from  []string
+	to    []string
+}
+
+func (d *DiffViewer) SetTitle(title string) *DiffViewer {
+	d.title = title
+	return d
+}
+
+// SetContent set text to be compared line by line
+func (d *DiffViewer) SetContent(from, to string) *DiffViewer {
+	d.from = splitLines(from)
+	d.to = splitLines(to)
+	return d
+}
+
+func (d *DiffViewer) HasChange() bool {
+	if len(d.from) != len(d.to) {
+		return true
+	}
+	for i := range d.from {
+		if d.from[i] != d.to[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DiffViewer) IsErrorView() bool {
+	return false
+}
+
+func (d *DiffViewer) View() {
+	color.New(color.Bold).Println(d.title)
+	removed := color.New(color.FgRed)
+	added := color.New(color.FgGreen)
+	for _, line := range diffLines(d.from, d.to) {
+		switch line.op {
+		case '-':
+			removed.Println("- " + line.text)
+		case '+':
+			added.Println("+ " + line.text)
+		default:
+			fmt.Println("  " + line.text)
+		}
+	}
+}
+
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// diffLines compute line diff using longest common subsequence
+func diffLines(from, to []string) []diffLine {
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		if from[i] == to[j] {
+			lines = append(lines, diffLine{op: ' ', text: from[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			lines = append(lines, diffLine{op: '-', text: from[i]})
+			i++
+		} else {
+			lines = append(lines, diffLine{op: '+', text: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		lines = append(lines, diffLine{op: '-', text: from[i]})
+	}
+	for ; j < len(to); j++ {
+		lines = append(lines, diffLine{op: '+', text: to[j]})
+	}
+	return lines
+}
+
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.TrimRight(text, "\n"), "\n")
+}
@@ -1,40 +1,82 @@
 package viewer
 
 import (
+	ctltime "cloudctl/time"
 	"fmt"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 )
 
 type Row []interface{}
 
+// Fields is a row keyed by column name, names are matched ignoring case and punctuation
+type Fields map[string]interface{}
+
 type embedError struct {
 	err       error
 	errorType ErrorType
 }
 
+type tableColumn struct {
+	field string
+	title interface{}
+	// wide columns are hidden unless wide mode is enabled
+	wide bool
+}
+
 type TableViewer struct {
 	title      string
-	header     table.Row
-	rows       []table.Row
+	columns    []*tableColumn
+	rows       []Fields
 	embedError embedError
 }
 
 func (t *TableViewer) AddHeader(header Row) *TableViewer {
-	headerr := table.Row{}
+	t.columns = []*tableColumn{}
+	seen := map[string]int{}
 	for _, h := range header {
-		headerr = append(headerr, h)
+		field := fieldName(h)
+		// keep duplicated headers as separate fields
+		if seen[field]++; seen[field] > 1 {
+			field = fmt.Sprintf("%s%d", field, seen[field])
+		}
+		t.columns = append(t.columns, &tableColumn{field: field, title: h})
 	}
-	t.header = headerr
 	return t
 }
 
+// SetWideColumns hide columns from compact default view, they are shown with --wide or when selected explicitly
+func (t *TableViewer) SetWideColumns(headers ...string) *TableViewer {
+	wide := map[string]bool{}
+	for _, h := range headers {
+		wide[fieldName(h)] = true
+	}
+	for _, column := range t.columns {
+		column.wide = wide[column.field]
+	}
+	return t
+}
+
+// AddRow add values in header order
 func (t *TableViewer) AddRow(row Row) *TableViewer {
-	roww := table.Row{}
-	for _, r := range row {
-		roww = append(roww, r)
+	fields := Fields{}
+	for i, r := range row {
+		if i < len(t.columns) {
+			fields[t.columns[i].field] = r
+		}
+	}
+	t.rows = append(t.rows, fields)
+	return t
+}
+
+// AddFields add values by column name
+func (t *TableViewer) AddFields(fields Fields) *TableViewer {
+	normalized := Fields{}
+	for name, value := range fields {
+		normalized[fieldName(name)] = value
 	}
-	t.rows = append(t.rows, roww)
+	t.rows = append(t.rows, normalized)
 	return t
 }
 
@@ -61,14 +103,134 @@ func (t *TableViewer) SetError(err error, errorType ErrorType) *TableViewer {
 func (t *TableViewer) IsErrorView() bool {
 	return false
 }
+
 func (t *TableViewer) View() {
 	writer := table.NewWriter()
 	writer.SetTitle(t.title)
 	writer.SetAutoIndex(true)
-	writer.AppendHeader(t.header)
+	header, values := t.selectColumns(tableColumnOptions)
+	writer.AppendHeader(header)
 	for _, row := range t.rows {
-		writer.AppendRow(row)
+		writer.AppendRow(values(row))
 	}
 	fmt.Println(writer.Render())
 
 }
+
+// findColumn match field against column name, or column name without unit e.g. size matches Size(GiB)
+func (t *TableViewer) findColumn(field string) *tableColumn {
+	field = fieldName(field)
+	for _, column := range t.columns {
+		if column.field == field {
+			return column
+		}
+	}
+	for _, column := range t.columns {
+		if shortFieldName(column.title) == field {
+			return column
+		}
+	}
+	return nil
+}
+
+// selectColumns return header & row renderer, selection not matching any column of this table falls back to default view
+// so it can be applied to every table of a command, selection not matching any rendered table is reported by ColumnSelectionError
+func (t *TableViewer) selectColumns(options columnOptions) (table.Row, func(Fields) table.Row) {
+	tableColumnUsage.rendered = true
+	for _, column := range t.columns {
+		tableColumnUsage.addAvailable(fmt.Sprint(column.title))
+	}
+	if len(options.customColumns) != 0 {
+		header := table.Row{}
+		fields := []string{}
+		matched := false
+		for _, custom := range options.customColumns {
+			header = append(header, custom.Name)
+			field := fieldName(custom.Field)
+			if column := t.findColumn(custom.Field); column != nil {
+				field = column.field
+			}
+			if t.hasField(field) {
+				matched = true
+				tableColumnUsage.matched[fieldName(custom.Field)] = true
+			}
+			fields = append(fields, field)
+		}
+		if matched {
+			return header, func(row Fields) table.Row {
+				values := table.Row{}
+				for i, field := range fields {
+					value, ok := row[field]
+					if ok {
+						value, ok = lookupPath(value, options.customColumns[i].Path)
+					}
+					if !ok {
+						value = MISSING_FIELD_VALUE
+					}
+					values = append(values, cellValue(value))
+				}
+				return values
+			}
+		}
+	}
+
+	columns := []*tableColumn{}
+	for _, name := range options.columns {
+		if column := t.findColumn(name); column != nil {
+			columns = append(columns, column)
+			tableColumnUsage.matched[fieldName(name)] = true
+		}
+	}
+	if len(columns) == 0 {
+		for _, column := range t.columns {
+			if options.wide || !column.wide {
+				columns = append(columns, column)
+			}
+		}
+	}
+	header := table.Row{}
+	for _, column := range columns {
+		header = append(header, column.title)
+	}
+	return header, func(row Fields) table.Row {
+		values := table.Row{}
+		for _, column := range columns {
+			value, ok := row[column.field]
+			if !ok {
+				value = ""
+			}
+			values = append(values, cellValue(value))
+		}
+		return values
+	}
+}
+
+// hasField check field is a column or a value of any row, rows may have fields which are only shown as custom columns
+func (t *TableViewer) hasField(field string) bool {
+	for _, column := range t.columns {
+		if column.field == field {
+			return true
+		}
+	}
+	for _, row := range t.rows {
+		if _, ok := row[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cellValue render time in --time-format and Map as key=value lines
+func cellValue(value interface{}) interface{} {
+	switch t := value.(type) {
+	case Map:
+		return t.String()
+	case time.Time:
+		return ctltime.Format(t)
+	case *time.Time:
+		if t != nil {
+			return ctltime.Format(*t)
+		}
+	}
+	return value
+}
@@ -0,0 +1,34 @@
+package viewer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+type TextViewer struct {
+	title   string
+	content string
+}
+
+func (t *TextViewer) SetTitle(title string) *TextViewer {
+	t.title = title
+	return t
+}
+
+func (t *TextViewer) SetContent(content string) *TextViewer {
+	t.content = content
+	return t
+}
+
+func (t *TextViewer) IsErrorView() bool {
+	return false
+}
+
+func (t *TextViewer) View() {
+	if len(t.title) != 0 {
+		color.New(color.Bold).Println(t.title)
+	}
+	fmt.Println(strings.TrimRight(t.content, "\n"))
+}
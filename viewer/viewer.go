@@ -18,3 +18,11 @@ func NewErrorViewer() *ErrorViewer {
 func NewCompoundViewer() *CompoundViewer {
 	return &CompoundViewer{}
 }
+
+func NewDiffViewer() *DiffViewer {
+	return &DiffViewer{}
+}
+
+func NewTextViewer() *TextViewer {
+	return &TextViewer{}
+}